@@ -38,11 +38,18 @@ func main() {
 	// Initialize services
 	binanceClient := binance.NewClient()
 	marketService := market.NewService(binanceClient)
-	analysisService := analysis.NewService()
+	if cfg.HistoryWindow > analysis.MaxHistoryWindow {
+		fmt.Printf("Warning: HISTORY_WINDOW %d is above the maximum; using %d.\n", cfg.HistoryWindow, analysis.MaxHistoryWindow)
+	}
+	analysisService := analysis.NewService(cfg.HistoryWindow)
 	aiService := ai.NewService(cfg.AIAPIKey, cfg.AIEndpoint)
 
 	// Create and start the bot
-	app := bot.NewBot(dg, marketService, analysisService, aiService)
+	app, err := bot.NewBot(dg, marketService, analysisService, aiService, cfg.Timeframes)
+	if err != nil {
+		fmt.Println("Error creating bot:", err)
+		return
+	}
 	if err := app.Start(); err != nil {
 		fmt.Println("Error starting bot:", err)
 		return
@@ -4,19 +4,42 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"text/template"
 	"tv-bot-go/internal/analysis"
 )
 
 const masterPromptTemplate = `
-As a crypto market analyst, provide a brief analysis for {{.Symbol}} based on the 1-hour and 15-minute timeframes.
+As a crypto market analyst, provide a brief analysis for {{.Symbol}} based on the {{ timeframes .Analyses }} timeframes, listed from the first (primary) to the last.
 Focus on trend, momentum, and volume. Do not provide any financial advice, trading signals, or price predictions.
+Each timeframe starts with a market regime (trending_up, trending_down, ranging or high_volatility) and a 0-1 confidence derived from ADX, ATR and Bollinger bandwidth percentiles and the 50 SMA slope (in ATRs); match the tone of the summary to the regime.
+Moving averages compare price with the 20/50/200 SMAs; the cross is "golden" when the 50 SMA is above the 200 SMA and "death" otherwise.
+Supertrend (10, 3x ATR) and Parabolic SAR (0.02/0.2) give the trailing-stop trend direction and how many candles ago it last flipped (-1 if it did not flip in the analyzed data).
+Momentum is described by RSI (14), Stochastic RSI (14,14,3,3), CCI (20), Williams %R (14) and ROC (12, in percent).
+Volatility includes ATR (14), Bollinger Bands (20,2) and Keltner Channels (20, 1.5x ATR 10); a squeeze means the Bollinger Bands are inside the Keltner Channel.
+VWAP positions compare the latest close with the daily, weekly and (if requested) anchored VWAPs and their standard deviation bands; a partial session VWAP starts at the first fetched candle rather than the session open, so weigh it less.
+Ichimoku (9,26,52,26) reports price versus the cloud, the latest Tenkan/Kijun cross, the projected cloud color and any upcoming cloud twist.
+Patterns lists candlestick patterns found on the last few closed candles, with a 0-1 strength score and how many candles ago they completed.
+Divergences lists recent regular and hidden divergences between price swings and the MACD histogram, OBV, MFI or RSI.
+Events lists recent crossover and threshold events on closed candles (MACD signal and zero-line crosses, MFI entering overbought above 80 or oversold below 20, ADX crossing 25, OBV crossing its 20 SMA) with the candle open time and how many candles ago they occurred.
+The volume profile gives the point of control (POC), the 70% value area and high/low volume nodes over the analyzed window.
+Order flow uses taker buy volume: delta and cumulative volume delta (CVD), the taker buy ratio (0.5 is balanced) and any CVD/price divergences.
+Volume describes the latest closed candle: relative volume (RVOL, versus the previous 20 candles) and its status, flagged as abnormal when high, extreme or low, plus the A/D line trend, Chaikin Money Flow (20), Chaikin Oscillator (3,10), Force Index (13), Ease of Movement (14) and the volume oscillator (5,10, percent).
+Statistics describe the last 100 closed candles: per-candle realized, Parkinson and Garman-Klass volatility and annualized volatility (percent), z-scores of the latest return and close versus the last 20 candles, skewness, excess kurtosis, lag-1 return autocorrelation and a Hurst exponent (above 0.5 trending, below 0.5 mean reverting).
+History holds the last few values of key indicators (oldest first) with their slope per candle, window min/max, the current rising (+) or falling (-) streak and, where relevant, the position versus a reference line (e.g. MACD vs signal, RSI vs 50, ADX vs 25, OBV vs its 20 SMA) and candles since the last cross (-1 if none). History covers closed candles only, while the headline indicator values include the still-forming latest candle, so the last history value can differ from the headline value.
+Custom indicators, if present, were requested by the user and are keyed by their spec, e.g. "ema(200)".
+Levels (first timeframe only) holds daily and weekly pivot points, clustered support/resistance zones and the nearest levels above and below price.
 
-1-Hour Analysis:
-{{ formatAnalysis .Analysis1h }}
+{{ range .Analyses }}{{ .Timeframe }} Analysis:
+{{ formatAnalysis . }}
 
-15-Minute Analysis:
-{{ formatAnalysis .Analysis15m }}
+{{ end -}}
+
+Multi-Timeframe Confluence:
+{{ formatConfluence .Confluence }}
+
+The confluence score (-100 to 100) combines trend, momentum and volume votes from every timeframe; lead with its bias and mention any factor that is not aligned across timeframes.
+If a timeframe name includes a chart type (heikin-ashi, renko or range), its indicators were calculated on that smoothed construction rather than on raw candles; a number in parentheses is the fixed brick or bar size.
 
 Synthesize these findings into a short, neutral summary.
 `
@@ -24,16 +47,17 @@ Synthesize these findings into a short, neutral summary.
 var tmpl *template.Template
 
 func init() {
-	funcs := template.FuncMap{"formatAnalysis": formatAnalysis}
+	funcs := template.FuncMap{"formatAnalysis": formatAnalysis, "formatConfluence": formatConfluence, "timeframes": timeframes}
 	tmpl = template.Must(template.New("prompt").Funcs(funcs).Parse(masterPromptTemplate))
 }
 
 // BuildPrompt creates the final prompt string sent to the AI.
-func BuildPrompt(symbol string, analysis1h, analysis15m *analysis.TechnicalAnalysis) string {
+// Analyses are ordered from the primary timeframe to the last one.
+func BuildPrompt(symbol string, analyses []*analysis.TechnicalAnalysis, confluence *analysis.Confluence) string {
 	data := map[string]interface{}{
-		"Symbol":      symbol,
-		"Analysis1h":  analysis1h,
-		"Analysis15m": analysis15m,
+		"Symbol":     symbol,
+		"Analyses":   analyses,
+		"Confluence": confluence,
 	}
 
 	var buf bytes.Buffer
@@ -57,3 +81,29 @@ func formatAnalysis(analysis *analysis.TechnicalAnalysis) string {
 	}
 	return string(b)
 }
+
+// timeframes lists the timeframe names of the analyses, e.g. "4h, 1h and 15m".
+func timeframes(analyses []*analysis.TechnicalAnalysis) string {
+	var names []string
+	for _, a := range analyses {
+		if a != nil {
+			names = append(names, a.Timeframe)
+		}
+	}
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// formatConfluence formats the confluence score for the prompt.
+func formatConfluence(confluence *analysis.Confluence) string {
+	if confluence == nil {
+		return "No data available."
+	}
+	b, err := json.MarshalIndent(confluence, "", "  ")
+	if err != nil {
+		return "Error formatting confluence."
+	}
+	return string(b)
+}
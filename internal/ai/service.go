@@ -27,14 +27,15 @@ func NewService(apiKey, endpoint string) *Service {
 
 // AnalysisPayload is the data sent to the AI.
 type AnalysisPayload struct {
-	Symbol      string                           `json:"symbol"`
-	Analysis1h  *analysis.TechnicalAnalysis `json:"analysis_1h"`
-	Analysis15m *analysis.TechnicalAnalysis `json:"analysis_15m"`
+	Symbol     string                        `json:"symbol"`
+	Analyses   []*analysis.TechnicalAnalysis `json:"analyses"`
+	Confluence *analysis.Confluence          `json:"confluence"`
 }
 
-// GenerateAnalysis sends the analysis to the AI and returns the interpretation.
-func (s *Service) GenerateAnalysis(ctx context.Context, symbol string, analysis1h, analysis15m *analysis.TechnicalAnalysis) (string, error) {
-	prompt := BuildPrompt(symbol, analysis1h, analysis15m)
+// GenerateAnalysis sends the analyses, ordered from the primary timeframe, to
+// the AI and returns the interpretation.
+func (s *Service) GenerateAnalysis(ctx context.Context, symbol string, analyses []*analysis.TechnicalAnalysis, confluence *analysis.Confluence) (string, error) {
+	prompt := BuildPrompt(symbol, analyses, confluence)
 
 	payload := map[string]interface{}{
 		"model":    "deepseek-coder",
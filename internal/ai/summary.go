@@ -0,0 +1,151 @@
+package ai
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"tv-bot-go/internal/analysis"
+	"tv-bot-go/pkg/events"
+	"tv-bot-go/pkg/indicators"
+	"tv-bot-go/pkg/patterns"
+)
+
+// summaryTemplate renders the rule-based summary. Every sentence is derived
+// directly from the analysis, so the result is deterministic and neutral.
+const summaryTemplate = `{{ with .Confluence -}}
+Overall the timeframes lean **{{ .Bias }}** with a confluence score of {{ signed .Score }}{{ range .Factors }}; {{ .Name }} {{ signed .Score }}{{ if not .Aligned }} (mixed){{ end }}{{ end }}.
+{{ end -}}
+{{ range .Analyses }}
+**{{ .Timeframe }}:**
+{{- with .Regime }} The market is {{ words .Label }} ({{ percent .Confidence }} confidence, ADX {{ printf "%.1f" .ADX }}).{{ end }}
+{{- with .MovingAverages }} {{ maSentence . }}{{ end }}
+{{- with .Supertrend }} Supertrend points {{ .Direction }}{{ if .LastFlip }}, flipped {{ ago .BarsSinceFlip }}{{ end }}.{{ end }}
+{{- if .RSI }} RSI is {{ printf "%.1f" (deref .RSI) }} ({{ rsiZone (deref .RSI) }}){{ with .MACD }} and the MACD histogram is {{ sign .Histogram }}{{ end }}.{{ end }}
+{{- with .Volume }} Volume on the last closed candle is {{ printf "%.2f" .RVOL }}x average ({{ .Status }}), with CMF at {{ printf "%+.2f" .CMF }}.{{ end }}
+{{- with .Events }} Recent events: {{ eventList . }}.{{ end }}
+{{- with .Patterns }} Patterns: {{ patternList . }}.{{ end }}
+{{- with .Divergences }} Divergences: {{ divergenceList . }}.{{ end }}
+{{ end }}`
+
+var summaryTmpl = template.Must(template.New("summary").Funcs(template.FuncMap{
+	"signed":         func(v float64) string { return fmt.Sprintf("%+.0f", v) },
+	"percent":        func(v float64) string { return fmt.Sprintf("%.0f%%", 100*v) },
+	"words":          func(s string) string { return strings.ReplaceAll(s, "_", " ") },
+	"deref":          func(v *float64) float64 { return *v },
+	"ago":            ago,
+	"sign":           sign,
+	"rsiZone":        rsiZone,
+	"maSentence":     maSentence,
+	"eventList":      eventList,
+	"patternList":    patternList,
+	"divergenceList": divergenceList,
+}).Parse(summaryTemplate))
+
+// BuildSummary turns the analyses into a short, neutral narrative without
+// calling the AI. It is used in fast mode and when the AI is unavailable.
+func BuildSummary(symbol string, confluence *analysis.Confluence, analyses ...*analysis.TechnicalAnalysis) string {
+	var present []*analysis.TechnicalAnalysis
+	for _, a := range analyses {
+		if a != nil {
+			present = append(present, a)
+		}
+	}
+	if len(present) == 0 {
+		return fmt.Sprintf("No market data is available for %s.", symbol)
+	}
+
+	var buf bytes.Buffer
+	data := map[string]interface{}{"Confluence": confluence, "Analyses": present}
+	if err := summaryTmpl.Execute(&buf, data); err != nil {
+		return fmt.Sprintf("Error executing summary template: %s", err)
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// ago describes how many candles back something happened.
+func ago(n int) string {
+	switch n {
+	case 0:
+		return "on the latest candle"
+	case 1:
+		return "1 candle ago"
+	default:
+		return fmt.Sprintf("%d candles ago", n)
+	}
+}
+
+func sign(v float64) string {
+	switch {
+	case v > 0:
+		return "positive"
+	case v < 0:
+		return "negative"
+	default:
+		return "flat"
+	}
+}
+
+func rsiZone(rsi float64) string {
+	switch {
+	case rsi >= 70:
+		return "overbought"
+	case rsi <= 30:
+		return "oversold"
+	default:
+		return "neutral"
+	}
+}
+
+// maSentence describes price versus the SMAs and the cross state.
+func maSentence(ma *analysis.MovingAverages) string {
+	var above, below []string
+	for _, p := range ma.Averages {
+		if p.Above {
+			above = append(above, fmt.Sprint(p.Period))
+		} else {
+			below = append(below, fmt.Sprint(p.Period))
+		}
+	}
+
+	var parts []string
+	if len(above) > 0 {
+		parts = append(parts, "above the "+strings.Join(above, "/")+" SMA")
+	}
+	if len(below) > 0 {
+		parts = append(parts, "below the "+strings.Join(below, "/")+" SMA")
+	}
+	sentence := "Price is " + strings.Join(parts, " and ")
+	if c := ma.Cross; c != nil {
+		sentence += fmt.Sprintf(", with a %s cross in place", c.Status)
+		if c.BarsSinceCross >= 0 {
+			sentence += fmt.Sprintf(" (crossed %s)", ago(c.BarsSinceCross))
+		}
+	}
+	return sentence + "."
+}
+
+// eventList describes the most recent events, newest first.
+func eventList(list []events.Event) string {
+	var items []string
+	for i := len(list) - 1; i >= max(len(list)-3, 0); i-- {
+		items = append(items, fmt.Sprintf("%s (%s)", list[i], ago(list[i].BarsAgo)))
+	}
+	return strings.Join(items, "; ")
+}
+
+func patternList(list []patterns.Pattern) string {
+	var items []string
+	for _, p := range list {
+		items = append(items, fmt.Sprintf("%s (%s)", strings.ReplaceAll(p.Name, "_", " "), ago(p.BarsAgo)))
+	}
+	return strings.Join(items, ", ")
+}
+
+func divergenceList(list []indicators.Divergence) string {
+	var items []string
+	for _, d := range list {
+		items = append(items, fmt.Sprintf("%s %s (%s)", strings.ReplaceAll(string(d.Kind), "_", " "), d.Indicator, ago(d.BarsAgo)))
+	}
+	return strings.Join(items, ", ")
+}
@@ -1,25 +1,65 @@
 package analysis
 
 import (
+	"fmt"
+	"time"
 	"tv-bot-go/internal/binance"
+	"tv-bot-go/pkg/events"
 	"tv-bot-go/pkg/indicators"
+	"tv-bot-go/pkg/patterns"
 )
 
+// patternLookback is the number of closed candles scanned for candlestick patterns.
+const patternLookback = 5
+
 // TechnicalAnalysis holds the calculated indicators for a specific timeframe.
 type TechnicalAnalysis struct {
-	Timeframe   string                    `json:"timeframe"`
-	MACD        *indicators.MACDIndicator `json:"macd,omitempty"`
-	ADX         float64                   `json:"adx,omitempty"`
-	OBV         float64                   `json:"obv,omitempty"`
-	MFI         float64                   `json:"mfi,omitempty"`
+	Timeframe      string                        `json:"timeframe"`
+	Regime         *Regime                       `json:"regime,omitempty"`
+	MovingAverages *MovingAverages               `json:"moving_averages,omitempty"`
+	MACD           *indicators.MACDIndicator     `json:"macd,omitempty"`
+	ADX            float64                       `json:"adx,omitempty"`
+	Supertrend     *TrendStopState               `json:"supertrend,omitempty"`
+	ParabolicSAR   *TrendStopState               `json:"parabolic_sar,omitempty"`
+	OBV            float64                       `json:"obv,omitempty"`
+	MFI            float64                       `json:"mfi,omitempty"`
+	RSI            *float64                      `json:"rsi,omitempty"`
+	StochRSI       *indicators.StochRSIIndicator `json:"stoch_rsi,omitempty"`
+	CCI            *float64                      `json:"cci,omitempty"`
+	WilliamsR      *float64                      `json:"williams_r,omitempty"`
+	ROC            *float64                      `json:"roc,omitempty"`
+	Volatility     *Volatility                   `json:"volatility,omitempty"`
+	VWAP           *VWAPAnalysis                 `json:"vwap,omitempty"`
+	Ichimoku       *indicators.IchimokuState     `json:"ichimoku,omitempty"`
+	Patterns       []patterns.Pattern            `json:"patterns,omitempty"`
+	Divergences    []indicators.Divergence       `json:"divergences,omitempty"`
+	Events         []events.Event                `json:"events,omitempty"`
+	Levels         *Levels                       `json:"levels,omitempty"`
+	VolumeProfile  *VolumeProfile                `json:"volume_profile,omitempty"`
+	OrderFlow      *OrderFlow                    `json:"order_flow,omitempty"`
+	Volume         *VolumeHealth                 `json:"volume,omitempty"`
+	Statistics     *Statistics                   `json:"statistics,omitempty"`
+	// History holds the recent values of key indicators, keyed by indicator name.
+	History map[string]*IndicatorHistory `json:"history,omitempty"`
+	// Custom holds the latest outputs of indicators requested by spec.
+	Custom map[string]map[string]float64 `json:"custom_indicators,omitempty"`
 }
 
+// MaxHistoryWindow is the largest number of values kept per indicator history.
+// Longer histories add little for the AI but inflate every prompt.
+const MaxHistoryWindow = 50
+
 // Service performs technical analysis on market data.
-type Service struct{}
+type Service struct {
+	// historyWindow is the number of recent values kept per indicator history.
+	historyWindow int
+}
 
-// NewService creates a new analysis service.
-func NewService() *Service {
-	return &Service{}
+// NewService creates a new analysis service. historyWindow is the number of
+// recent indicator values carried in each analysis, clamped to
+// MaxHistoryWindow; 0 disables the history.
+func NewService(historyWindow int) *Service {
+	return &Service{historyWindow: min(max(historyWindow, 0), MaxHistoryWindow)}
 }
 
 // AnalyzeKlines performs a full technical analysis on a slice of klines.
@@ -34,30 +74,141 @@ func (s *Service) AnalyzeKlines(klines []binance.Kline, timeframe string) *Techn
 	volumes := getSlice(klines, "volume")
 
 	analysis := &TechnicalAnalysis{Timeframe: timeframe}
+	history := newHistoryBuilder(s.historyWindow, len(klines)-len(closedKlines(klines)))
+
+	analysis.Regime = analyzeRegime(highs, lows, closes)
+	analysis.MovingAverages = analyzeMovingAverages(closes)
 
 	// Calculate MACD
 	if macdResult := indicators.CalculateMACD(closes, 12, 26, 9); len(macdResult) > 0 {
 		analysis.MACD = &macdResult[len(macdResult)-1]
+		macdLine := make([]float64, len(macdResult))
+		signal := make([]float64, len(macdResult))
+		histogram := make([]float64, len(macdResult))
+		for i, m := range macdResult {
+			macdLine[i], signal[i], histogram[i] = m.MACD, m.Signal, m.Histogram
+		}
+		history.add("macd", macdLine, &reference{name: "signal", values: signal})
+		history.add("macd_histogram", histogram, level("0", 0))
 	}
 
 	// Calculate ADX
-	if adxResult := indicators.CalculateADX(highs, lows, closes, 14); len(adxResult) > 0 {
+	adxPeriod := 14
+	if adxResult := indicators.CalculateADX(highs, lows, closes, adxPeriod); len(adxResult) > 0 {
 		analysis.ADX = adxResult[len(adxResult)-1]
+		// The first period-1 values are the warm-up of the smoothing.
+		history.add("adx", adxResult[adxPeriod-1:], level("25", 25))
 	}
 
+	// Calculate Supertrend and Parabolic SAR
+	analysis.Supertrend = newTrendStopState(indicators.CalculateSupertrend(highs, lows, closes, 10, 3), closes)
+	analysis.ParabolicSAR = newTrendStopState(indicators.CalculateParabolicSAR(highs, lows, 0.02, 0.2), closes)
+
 	// Calculate OBV
 	if obvResult := indicators.CalculateOBV(closes, volumes); len(obvResult) > 0 {
 		analysis.OBV = obvResult[len(obvResult)-1]
+		var ref *reference
+		if obvMA := indicators.CalculateSMA(obvResult, 20); len(obvMA) > 0 {
+			ref = &reference{name: "sma20", values: obvMA}
+		}
+		history.add("obv", obvResult, ref)
 	}
 
 	// Calculate MFI
 	if mfiResult := indicators.CalculateMFI(highs, lows, closes, volumes, 14); len(mfiResult) > 0 {
 		analysis.MFI = mfiResult[len(mfiResult)-1]
+		history.add("mfi", mfiResult, level("50", 50))
+	}
+
+	// Calculate RSI
+	if rsiResult := indicators.CalculateRSI(closes, 14); len(rsiResult) > 0 {
+		analysis.RSI = &rsiResult[len(rsiResult)-1]
+		history.add("rsi", rsiResult, level("50", 50))
 	}
 
+	// Calculate Stochastic RSI
+	if stochRSIResult := indicators.CalculateStochRSI(closes, 14, 14, 3, 3); len(stochRSIResult) > 0 {
+		analysis.StochRSI = &stochRSIResult[len(stochRSIResult)-1]
+		k := make([]float64, len(stochRSIResult))
+		d := make([]float64, len(stochRSIResult))
+		for i, v := range stochRSIResult {
+			k[i], d[i] = v.K, v.D
+		}
+		history.add("stoch_rsi_k", k, &reference{name: "d", values: d})
+	}
+
+	// Calculate CCI
+	if cciResult := indicators.CalculateCCI(highs, lows, closes, 20); len(cciResult) > 0 {
+		analysis.CCI = &cciResult[len(cciResult)-1]
+		history.add("cci", cciResult, level("0", 0))
+	}
+
+	// Calculate Williams %R
+	if wrResult := indicators.CalculateWilliamsR(highs, lows, closes, 14); len(wrResult) > 0 {
+		analysis.WilliamsR = &wrResult[len(wrResult)-1]
+		history.add("williams_r", wrResult, level("-50", -50))
+	}
+
+	// Calculate ROC
+	if rocResult := indicators.CalculateROC(closes, 12); len(rocResult) > 0 {
+		analysis.ROC = &rocResult[len(rocResult)-1]
+		history.add("roc", rocResult, level("0", 0))
+	}
+
+	analysis.Volatility = analyzeVolatility(highs, lows, closes)
+	analysis.VWAP = analyzeVWAP(klines)
+	analysis.VolumeProfile = analyzeVolumeProfile(klines)
+	analysis.OrderFlow = analyzeOrderFlow(klines)
+	analysis.Volume = analyzeVolume(klines)
+	analysis.Statistics = analyzeStatistics(klines)
+
+	// Calculate Ichimoku
+	ichimoku := indicators.CalculateIchimoku(highs, lows, closes, 9, 26, 52, 26)
+	analysis.Ichimoku = indicators.ClassifyIchimoku(ichimoku, closes)
+
+	// Scan the last few closed candles for candlestick patterns
+	analysis.Patterns = patterns.Scan(closedKlines(klines), patternLookback)
+
+	// Detect recent price/indicator divergences
+	analysis.Divergences = recentDivergences(s.DetectDivergences(klines))
+
+	// Detect recent crossover and threshold events
+	analysis.Events = recentEvents(s.DetectEvents(klines))
+
+	analysis.History = history.result()
+
 	return analysis
 }
 
+// AnchorVWAP adds a VWAP anchored at the given time to an existing analysis.
+// It returns an error if the anchor is outside the klines, since the VWAP
+// would otherwise start somewhere other than the anchor.
+func (s *Service) AnchorVWAP(analysis *TechnicalAnalysis, klines []binance.Kline, anchor time.Time) error {
+	if analysis == nil || analysis.VWAP == nil {
+		return nil
+	}
+	anchorMillis := anchor.UnixMilli()
+	if first := klines[0].OpenTime; anchorMillis < first {
+		return fmt.Errorf("anchor is before the first %s candle (%s)", analysis.Timeframe, time.UnixMilli(first).UTC().Format(time.RFC3339))
+	}
+	result := indicators.CalculateAnchoredVWAP(klines, anchorMillis)
+	if len(result) == 0 {
+		return fmt.Errorf("anchor is after the last %s candle", analysis.Timeframe)
+	}
+	analysis.VWAP.Anchor = anchorMillis
+	analysis.VWAP.Anchored = newVWAPPosition(result[len(result)-1], klines[len(klines)-1].Close)
+	return nil
+}
+
+// closedKlines drops the last kline if it is still forming.
+// Binance includes the current, unfinished candle at the end of every response.
+func closedKlines(klines []binance.Kline) []binance.Kline {
+	if len(klines) > 0 && klines[len(klines)-1].CloseTime > time.Now().UnixMilli() {
+		return klines[:len(klines)-1]
+	}
+	return klines
+}
+
 // getSlice extracts a slice of float64 values from klines for a given field.
 func getSlice(klines []binance.Kline, field string) []float64 {
 	slice := make([]float64, len(klines))
@@ -0,0 +1,206 @@
+package analysis
+
+import "math"
+
+// Confluence factor names.
+const (
+	FactorTrend    = "trend"
+	FactorMomentum = "momentum"
+	FactorVolume   = "volume"
+)
+
+// confluenceWeights is the contribution of each factor to the overall score.
+var confluenceWeights = []struct {
+	name   string
+	weight float64
+}{
+	{FactorTrend, 0.4},
+	{FactorMomentum, 0.35},
+	{FactorVolume, 0.25},
+}
+
+// confluenceThreshold is the absolute score above which the bias is directional.
+const confluenceThreshold = 20
+
+// Confluence scores how strongly the timeframes agree on a direction.
+type Confluence struct {
+	// Bias is "bullish", "bearish" or "neutral".
+	Bias string `json:"bias"`
+	// Score ranges from -100 (every signal bearish) to 100 (every signal bullish).
+	Score   float64            `json:"score"`
+	Factors []ConfluenceFactor `json:"factors"`
+}
+
+// ConfluenceFactor is the score of one factor across all timeframes.
+type ConfluenceFactor struct {
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+	// Aligned is true when every timeframe leans the same way on this factor.
+	Aligned    bool              `json:"aligned"`
+	Timeframes []TimeframeFactor `json:"timeframes"`
+}
+
+// TimeframeFactor is the score of one factor on a single timeframe.
+type TimeframeFactor struct {
+	Timeframe string  `json:"timeframe"`
+	Score     float64 `json:"score"`
+	// Signals holds the vote of each signal: 1 bullish, -1 bearish, 0 neutral.
+	Signals map[string]int `json:"signals"`
+}
+
+// AnalyzeConfluence combines the trend, momentum and volume signals of every
+// timeframe into a single directional score. Each signal votes bullish,
+// bearish or neutral; a factor scores the mean vote of its signals, averaged
+// across timeframes, and the overall score is the weighted mean of the factors.
+func (s *Service) AnalyzeConfluence(analyses ...*TechnicalAnalysis) *Confluence {
+	result := &Confluence{Bias: "neutral"}
+	totalWeight := 0.0
+	for _, w := range confluenceWeights {
+		factor := ConfluenceFactor{Name: w.name, Aligned: true}
+		sign := 0.0
+		for _, a := range analyses {
+			if a == nil {
+				continue
+			}
+			signals := confluenceSignals(a, w.name)
+			if len(signals) == 0 {
+				continue
+			}
+			tf := TimeframeFactor{Timeframe: a.Timeframe, Signals: signals}
+			for _, vote := range signals {
+				tf.Score += float64(vote)
+			}
+			tf.Score = math.Round(100 * tf.Score / float64(len(signals)))
+
+			if tf.Score == 0 || (sign != 0 && math.Signbit(tf.Score) != math.Signbit(sign)) {
+				factor.Aligned = false
+			}
+			if sign == 0 {
+				sign = tf.Score
+			}
+			factor.Score += tf.Score
+			factor.Timeframes = append(factor.Timeframes, tf)
+		}
+		if len(factor.Timeframes) == 0 {
+			continue
+		}
+		factor.Score = math.Round(factor.Score / float64(len(factor.Timeframes)))
+		result.Score += w.weight * factor.Score
+		totalWeight += w.weight
+		result.Factors = append(result.Factors, factor)
+	}
+	if totalWeight == 0 {
+		return nil
+	}
+
+	result.Score = math.Round(result.Score / totalWeight)
+	switch {
+	case result.Score >= confluenceThreshold:
+		result.Bias = "bullish"
+	case result.Score <= -confluenceThreshold:
+		result.Bias = "bearish"
+	}
+	return result
+}
+
+// confluenceSignals returns the votes of the signals available for a factor.
+func confluenceSignals(a *TechnicalAnalysis, factor string) map[string]int {
+	signals := make(map[string]int)
+	switch factor {
+	case FactorTrend:
+		if a.MovingAverages != nil {
+			for _, ma := range a.MovingAverages.Averages {
+				if ma.Period == 50 {
+					signals["price_vs_sma50"] = boolVote(ma.Above)
+				}
+			}
+			if a.MovingAverages.Cross != nil {
+				signals["sma_cross"] = boolVote(a.MovingAverages.Cross.Status == "golden")
+			}
+		}
+		if a.Supertrend != nil {
+			signals["supertrend"] = boolVote(a.Supertrend.Direction == "up")
+		}
+		if a.ParabolicSAR != nil {
+			signals["parabolic_sar"] = boolVote(a.ParabolicSAR.Direction == "up")
+		}
+		if a.MACD != nil {
+			signals["macd_zero_line"] = signVote(a.MACD.MACD)
+		}
+		if a.Ichimoku != nil {
+			signals["ichimoku_cloud"] = labelVote(a.Ichimoku.PriceVsCloud, "above", "below")
+		}
+	case FactorMomentum:
+		if a.RSI != nil {
+			signals["rsi"] = bandVote(*a.RSI, 45, 55)
+		}
+		if a.StochRSI != nil {
+			signals["stoch_rsi"] = signVote(a.StochRSI.K - a.StochRSI.D)
+		}
+		if a.MACD != nil {
+			signals["macd_histogram"] = signVote(a.MACD.Histogram)
+		}
+		if a.CCI != nil {
+			signals["cci"] = bandVote(*a.CCI, -50, 50)
+		}
+		if a.ROC != nil {
+			signals["roc"] = signVote(*a.ROC)
+		}
+	case FactorVolume:
+		if a.OrderFlow != nil {
+			signals["taker_pressure"] = labelVote(a.OrderFlow.Pressure, "aggressive_buying", "aggressive_selling")
+			signals["cvd"] = signVote(a.OrderFlow.CVDChange)
+		}
+		if a.Volume != nil {
+			signals["cmf"] = signVote(a.Volume.CMF)
+		}
+		if a.MFI != 0 {
+			signals["mfi"] = bandVote(a.MFI, 45, 55)
+		}
+		if a.VWAP != nil && a.VWAP.Daily != nil {
+			signals["daily_vwap"] = signVote(a.VWAP.Daily.DistancePercent)
+		}
+	}
+	return signals
+}
+
+func boolVote(bullish bool) int {
+	if bullish {
+		return 1
+	}
+	return -1
+}
+
+func signVote(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// bandVote is bearish below lower, bullish above upper and neutral in between.
+func bandVote(v, lower, upper float64) int {
+	switch {
+	case v > upper:
+		return 1
+	case v < lower:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func labelVote(label, bullish, bearish string) int {
+	switch label {
+	case bullish:
+		return 1
+	case bearish:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,98 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"tv-bot-go/internal/binance"
+	"tv-bot-go/pkg/correlation"
+)
+
+const (
+	// BetaBenchmark is the symbol betas are measured against.
+	BetaBenchmark = "BTCUSDT"
+	// correlationWindow is the number of returns in the rolling correlation.
+	correlationWindow = 30
+	// minCorrelationSamples is the fewest returns a peer must share with the
+	// report symbol to be correlated with it.
+	minCorrelationSamples = 20
+)
+
+// CorrelationReport describes how a symbol moves with a set of peers.
+type CorrelationReport struct {
+	Symbol string `json:"symbol"`
+	// Window is the length of the rolling correlation, or the number of
+	// samples of a pair if that is smaller.
+	Window int `json:"window"`
+	// Beta is the beta of Symbol against BetaBenchmark, if the benchmark was fetched.
+	Beta float64 `json:"beta"`
+	// Pairs lists the peers ordered from the most to the least correlated.
+	Pairs []PairCorrelation `json:"pairs"`
+	// Dropped lists the peers that share too few candles with Symbol.
+	Dropped []string            `json:"dropped,omitempty"`
+	Matrix  *correlation.Matrix `json:"matrix"`
+}
+
+// PairCorrelation is the correlation between the report symbol and one peer.
+type PairCorrelation struct {
+	Symbol string `json:"symbol"`
+	// Samples is the number of returns the two symbols share.
+	Samples     int     `json:"samples"`
+	Correlation float64 `json:"correlation"`
+	// Rolling is the correlation over the most recent window only.
+	Rolling float64 `json:"rolling"`
+	// Beta is the beta of the peer against BetaBenchmark.
+	Beta float64 `json:"beta"`
+}
+
+// AnalyzeCorrelation correlates the log returns of symbol with every other
+// symbol in klines. Each peer is aligned with symbol separately on the closed
+// candles the two share, and peers sharing too few candles are dropped.
+func (s *Service) AnalyzeCorrelation(symbol string, klines map[string][]binance.Kline) (*CorrelationReport, error) {
+	if _, ok := klines[symbol]; !ok {
+		return nil, fmt.Errorf("no klines for %s", symbol)
+	}
+	if len(klines) < 2 {
+		return nil, fmt.Errorf("need at least one symbol to compare %s with", symbol)
+	}
+
+	closed := make(map[string][]binance.Kline, len(klines))
+	for sym, k := range klines {
+		closed[sym] = closedKlines(k)
+	}
+
+	report := &CorrelationReport{Symbol: symbol, Window: correlationWindow}
+	benchmark, hasBenchmark := closed[BetaBenchmark]
+	if hasBenchmark {
+		report.Beta = correlation.Beta(correlation.PairReturns(closed[symbol], benchmark))
+	}
+
+	kept := map[string][]binance.Kline{symbol: closed[symbol]}
+	for sym, k := range closed {
+		if sym == symbol {
+			continue
+		}
+		a, b := correlation.PairReturns(closed[symbol], k)
+		if len(a) < minCorrelationSamples {
+			report.Dropped = append(report.Dropped, sym)
+			continue
+		}
+		kept[sym] = k
+
+		pair := PairCorrelation{Symbol: sym, Samples: len(a), Correlation: correlation.Pearson(a, b)}
+		if rolling := correlation.RollingPearson(a, b, min(report.Window, len(a))); len(rolling) > 0 {
+			pair.Rolling = rolling[len(rolling)-1]
+		}
+		if hasBenchmark {
+			pair.Beta = correlation.Beta(correlation.PairReturns(k, benchmark))
+		}
+		report.Pairs = append(report.Pairs, pair)
+	}
+	if len(report.Pairs) == 0 {
+		return nil, fmt.Errorf("no symbol shares at least %d candles with %s", minCorrelationSamples+1, symbol)
+	}
+
+	sort.Strings(report.Dropped)
+	sort.Slice(report.Pairs, func(a, b int) bool { return report.Pairs[a].Correlation > report.Pairs[b].Correlation })
+	report.Matrix = correlation.NewPairwiseMatrix(kept)
+	return report, nil
+}
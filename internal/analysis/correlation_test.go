@@ -0,0 +1,177 @@
+package analysis
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"tv-bot-go/internal/binance"
+)
+
+// hourlyCloses returns closed hourly klines with the given closes, the first
+// opening offset hours after the Unix epoch.
+func hourlyCloses(offset int, closes []float64) []binance.Kline {
+	const hour = int64(3600000)
+	klines := make([]binance.Kline, len(closes))
+	for i, c := range closes {
+		open := int64(offset+i) * hour
+		klines[i] = binance.Kline{OpenTime: open, CloseTime: open + hour - 1, Open: c, High: c, Low: c, Close: c}
+	}
+	return klines
+}
+
+// randomWalk returns n positive prices from a seeded random walk.
+func randomWalk(seed int64, n int) []float64 {
+	r := rand.New(rand.NewSource(seed))
+	prices := make([]float64, n)
+	p := 100.0
+	for i := range prices {
+		p *= math.Exp(0.01 * r.NormFloat64())
+		prices[i] = p
+	}
+	return prices
+}
+
+// scaled returns prices raised to the given power, which scales their log returns.
+func scaled(prices []float64, power float64) []float64 {
+	result := make([]float64, len(prices))
+	for i, p := range prices {
+		result[i] = math.Pow(p, power)
+	}
+	return result
+}
+
+func TestAnalyzeCorrelation(t *testing.T) {
+	base := randomWalk(1, 100)
+	klines := map[string][]binance.Kline{
+		"AAAUSDT":     hourlyCloses(0, base),
+		BetaBenchmark: hourlyCloses(0, scaled(base, 0.5)),
+		"INVUSDT":     hourlyCloses(0, scaled(base, -1)),
+		"NOISEUSDT":   hourlyCloses(0, randomWalk(2, 100)),
+		// LATEUSDT only shares the last 40 candles; it must not shorten the other pairs.
+		"LATEUSDT": hourlyCloses(60, base[60:]),
+		// NEWUSDT shares too few candles and is dropped.
+		"NEWUSDT": hourlyCloses(90, base[90:]),
+	}
+
+	report, err := NewService(10).AnalyzeCorrelation("AAAUSDT", klines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Dropped) != 1 || report.Dropped[0] != "NEWUSDT" {
+		t.Errorf("dropped = %v, want [NEWUSDT]", report.Dropped)
+	}
+	if math.Abs(report.Beta-2) > 1e-9 {
+		t.Errorf("beta = %v, want 2", report.Beta)
+	}
+
+	want := map[string]struct {
+		samples     int
+		correlation float64
+	}{
+		BetaBenchmark: {99, 1},
+		"LATEUSDT":    {39, 1},
+		"INVUSDT":     {99, -1},
+	}
+	for _, pair := range report.Pairs {
+		w, ok := want[pair.Symbol]
+		if !ok {
+			continue
+		}
+		if pair.Samples != w.samples || math.Abs(pair.Correlation-w.correlation) > 1e-9 {
+			t.Errorf("%s: samples %d, correlation %v; want %d, %v", pair.Symbol, pair.Samples, pair.Correlation, w.samples, w.correlation)
+		}
+		if math.Abs(pair.Rolling-w.correlation) > 1e-9 {
+			t.Errorf("%s: rolling %v, want %v", pair.Symbol, pair.Rolling, w.correlation)
+		}
+	}
+	if len(report.Pairs) != 4 || report.Pairs[len(report.Pairs)-1].Symbol != "INVUSDT" {
+		t.Errorf("pairs are not ordered from most to least correlated: %+v", report.Pairs)
+	}
+	if len(report.Matrix.Symbols) != 5 {
+		t.Errorf("matrix symbols = %v, want the symbol and 4 kept peers", report.Matrix.Symbols)
+	}
+}
+
+func TestAnalyzeCorrelationErrors(t *testing.T) {
+	base := randomWalk(1, 100)
+	tests := []struct {
+		name   string
+		klines map[string][]binance.Kline
+	}{
+		{name: "missing symbol", klines: map[string][]binance.Kline{"BBBUSDT": hourlyCloses(0, base)}},
+		{name: "no peers", klines: map[string][]binance.Kline{"AAAUSDT": hourlyCloses(0, base)}},
+		{name: "no overlap", klines: map[string][]binance.Kline{
+			"AAAUSDT": hourlyCloses(0, base),
+			"BBBUSDT": hourlyCloses(100, base),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewService(10).AnalyzeCorrelation("AAAUSDT", tt.klines); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestRankStrength(t *testing.T) {
+	flat := make([]float64, 60)
+	rising := make([]float64, 60)
+	falling := make([]float64, 60)
+	for i := range flat {
+		flat[i] = 100
+		rising[i] = 100 + float64(i)
+		falling[i] = 100 - float64(i)/2
+	}
+	klines := map[string][]binance.Kline{
+		"BTCUSDT":  hourlyCloses(0, flat),
+		"ETHUSDT":  hourlyCloses(0, flat),
+		"UPUSDT":   hourlyCloses(0, rising),
+		"DOWNUSDT": hourlyCloses(0, falling),
+		// Too little shared history for the 20 candle lookback.
+		"NEWUSDT": hourlyCloses(50, rising[50:]),
+	}
+
+	scores, err := NewService(10).RankStrength(klines, []int{5, 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order []string
+	for _, s := range scores {
+		order = append(order, s.Symbol)
+	}
+	if len(order) != 4 || order[0] != "UPUSDT" || order[3] != "DOWNUSDT" {
+		t.Fatalf("order = %v, want UPUSDT first and DOWNUSDT last without NEWUSDT", order)
+	}
+	for _, rs := range scores[0].Against {
+		if rs.Trend != "rising" || rs.Percentile != 100 || rs.ChangePercent <= 0 {
+			t.Errorf("UPUSDT against %s over %d: %+v", rs.Benchmark, rs.Lookback, rs)
+		}
+	}
+
+	if _, err := NewService(10).RankStrength(klines, nil); err == nil {
+		t.Error("ranking without lookbacks succeeded")
+	}
+	delete(klines, "ETHUSDT")
+	if _, err := NewService(10).RankStrength(klines, []int{5}); err == nil {
+		t.Error("ranking without a benchmark succeeded")
+	}
+}
+
+func TestRelativeStrengthSeries(t *testing.T) {
+	symbol := hourlyCloses(0, []float64{10, 20, 30, 40})
+	// The benchmark is missing the second candle.
+	benchmark := append(hourlyCloses(0, []float64{5}), hourlyCloses(2, []float64{10, 0})...)
+	got := RelativeStrengthSeries(symbol, benchmark)
+	want := []float64{2, 3, 0}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+			break
+		}
+	}
+}
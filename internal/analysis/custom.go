@@ -0,0 +1,40 @@
+package analysis
+
+import (
+	"tv-bot-go/internal/binance"
+	"tv-bot-go/pkg/indicators"
+)
+
+// AddCustomIndicators evaluates indicator specs, such as "ema(200)" or
+// "bb(20,2.5)", and stores their latest values in the analysis keyed by the
+// resolved spec. Specs that need more klines than are available, and repeats
+// of a spec that resolve to the same parameters, are skipped.
+func (s *Service) AddCustomIndicators(analysis *TechnicalAnalysis, klines []binance.Kline, specs []indicators.Spec) error {
+	if analysis == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		def, resolved, err := indicators.DefaultRegistry.Resolve(spec)
+		if err != nil {
+			return err
+		}
+		if seen[resolved.String()] {
+			continue
+		}
+		seen[resolved.String()] = true
+		if len(klines) < def.Lookback(resolved.Params) {
+			continue
+		}
+		_, output, err := indicators.DefaultRegistry.Evaluate(resolved, klines)
+		if err != nil {
+			return err
+		}
+		if analysis.Custom == nil {
+			analysis.Custom = make(map[string]map[string]float64)
+		}
+		analysis.Custom[resolved.String()] = output.Latest()
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package analysis
+
+import (
+	"testing"
+	"tv-bot-go/internal/binance"
+	"tv-bot-go/pkg/indicators"
+)
+
+func TestAddCustomIndicators(t *testing.T) {
+	klines := make([]binance.Kline, 50)
+	for i := range klines {
+		p := 100 + float64(i%7)
+		klines[i] = binance.Kline{OpenTime: int64(i) * 60000, Open: p, High: p + 1, Low: p - 1, Close: p, Volume: 10}
+	}
+
+	tests := []struct {
+		name    string
+		specs   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "duplicates", specs: "ema, EMA(20) ema()", want: []string{"ema(20)"}},
+		{name: "distinct", specs: "ema(10) ema(20)", want: []string{"ema(10)", "ema(20)"}},
+		{name: "too few klines", specs: "ema(200) rsi", want: []string{"rsi(14)"}},
+		{name: "invalid", specs: "macd(26,12)", wantErr: true},
+		{name: "unknown", specs: "foo", wantErr: true},
+	}
+
+	s := NewService(10)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specs, err := indicators.ParseSpecs(tt.specs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			a := &TechnicalAnalysis{}
+			err = s.AddCustomIndicators(a, klines, specs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(a.Custom) != len(tt.want) {
+				t.Fatalf("got %v, want keys %v", a.Custom, tt.want)
+			}
+			for _, key := range tt.want {
+				if _, ok := a.Custom[key]; !ok {
+					t.Errorf("missing %s in %v", key, a.Custom)
+				}
+			}
+		})
+	}
+}
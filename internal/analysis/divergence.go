@@ -0,0 +1,57 @@
+package analysis
+
+import (
+	"tv-bot-go/internal/binance"
+	"tv-bot-go/pkg/indicators"
+)
+
+// recentDivergenceBars limits the divergences reported in a TechnicalAnalysis to
+// those whose second pivot is at most this many closed candles old.
+const recentDivergenceBars = 20
+
+// DetectDivergences finds regular and hidden divergences between price swings
+// and the MACD histogram, OBV, MFI and RSI on closed klines.
+func (s *Service) DetectDivergences(klines []binance.Kline) []indicators.Divergence {
+	klines = closedKlines(klines)
+	if len(klines) == 0 {
+		return nil
+	}
+
+	closes := getSlice(klines, "close")
+	highs := getSlice(klines, "high")
+	lows := getSlice(klines, "low")
+	volumes := getSlice(klines, "volume")
+
+	macd := indicators.CalculateMACD(closes, 12, 26, 9)
+	histogram := make([]float64, len(macd))
+	for i, m := range macd {
+		histogram[i] = m.Histogram
+	}
+
+	series := []struct {
+		name   string
+		values []float64
+	}{
+		{"macd_histogram", histogram},
+		{"obv", indicators.CalculateOBV(closes, volumes)},
+		{"mfi", indicators.CalculateMFI(highs, lows, closes, volumes, 14)},
+		{"rsi", indicators.CalculateRSI(closes, 14)},
+	}
+
+	var divergences []indicators.Divergence
+	for _, line := range series {
+		divergences = append(divergences, indicators.FindDivergences(line.name, highs, lows, line.values, indicators.DefaultDivergenceConfig)...)
+	}
+	return divergences
+}
+
+// recentDivergences keeps only the divergences that completed recently.
+func recentDivergences(divergences []indicators.Divergence) []indicators.Divergence {
+	var recent []indicators.Divergence
+	for _, d := range divergences {
+		if d.BarsAgo <= recentDivergenceBars {
+			recent = append(recent, d)
+		}
+	}
+	return recent
+}
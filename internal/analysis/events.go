@@ -0,0 +1,31 @@
+package analysis
+
+import (
+	"tv-bot-go/internal/binance"
+	"tv-bot-go/pkg/events"
+)
+
+// recentEventBars limits the events reported in a TechnicalAnalysis to those
+// at most this many closed candles old.
+const recentEventBars = 20
+
+// DetectEvents runs the default event detectors over closed klines, so that
+// an event cannot disappear while its candle is still forming.
+func (s *Service) DetectEvents(klines []binance.Kline) []events.Event {
+	klines = closedKlines(klines)
+	if len(klines) == 0 {
+		return nil
+	}
+	return events.Detect(klines, events.Default...)
+}
+
+// recentEvents keeps only the events that occurred recently.
+func recentEvents(found []events.Event) []events.Event {
+	var recent []events.Event
+	for _, e := range found {
+		if e.BarsAgo <= recentEventBars {
+			recent = append(recent, e)
+		}
+	}
+	return recent
+}
@@ -0,0 +1,143 @@
+package analysis
+
+import "math"
+
+// IndicatorHistory carries the recent values of an indicator and facts derived
+// from them. Values are ordered from the oldest to the latest candle.
+type IndicatorHistory struct {
+	Values []float64 `json:"values"`
+	// Slope is the least-squares change per candle over the window.
+	Slope float64 `json:"slope"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	// Streak is the number of consecutive candles the indicator has risen
+	// (positive) or fallen (negative) up to the latest one.
+	Streak int           `json:"streak"`
+	Cross  *HistoryCross `json:"cross,omitempty"`
+}
+
+// HistoryCross describes the indicator's position relative to a reference line.
+type HistoryCross struct {
+	// Reference names the line, e.g. "signal" or "50".
+	Reference string `json:"reference"`
+	// Position is "above" or "below" the reference on the latest candle.
+	Position string `json:"position"`
+	// BarsSince is the number of candles since the indicator last crossed the
+	// reference, or -1 if it did not cross within the analyzed series.
+	BarsSince int `json:"bars_since"`
+}
+
+// reference is a line an indicator is compared against. Its values are
+// aligned to the end of the indicator series.
+type reference struct {
+	name   string
+	values []float64
+	// constant marks a horizontal line holding a single value.
+	constant bool
+}
+
+// level is a horizontal reference line.
+func level(name string, value float64) *reference {
+	return &reference{name: name, values: []float64{value}, constant: true}
+}
+
+// at returns the reference value i candles before the latest one.
+func (r *reference) at(i int) (float64, bool) {
+	if r.constant {
+		return r.values[0], true
+	}
+	if i >= len(r.values) {
+		return 0, false
+	}
+	return r.values[len(r.values)-1-i], true
+}
+
+// historyBuilder collects indicator histories while an analysis is computed.
+// Histories cover closed klines only, like events and volume health, so that a
+// cross is counted from the same candle everywhere in the analysis.
+type historyBuilder struct {
+	window int
+	// forming is the number of still-forming klines at the end of the series.
+	forming int
+	entries map[string]*IndicatorHistory
+}
+
+func newHistoryBuilder(window, forming int) *historyBuilder {
+	return &historyBuilder{window: window, forming: forming, entries: make(map[string]*IndicatorHistory)}
+}
+
+// add records the history of series, comparing it with ref if it is not nil.
+// Both are aligned to the end of the analyzed klines; the values of forming
+// klines are dropped.
+func (h *historyBuilder) add(name string, series []float64, ref *reference) {
+	series = series[:max(len(series)-h.forming, 0)]
+	if h.window <= 0 || len(series) == 0 {
+		return
+	}
+	if ref != nil && !ref.constant {
+		ref = &reference{name: ref.name, values: ref.values[:max(len(ref.values)-h.forming, 0)]}
+		if len(ref.values) == 0 {
+			ref = nil
+		}
+	}
+
+	values := make([]float64, min(h.window, len(series)))
+	copy(values, series[len(series)-len(values):])
+	history := &IndicatorHistory{
+		Values: values,
+		Slope:  linearSlope(values),
+		Min:    math.Inf(1),
+		Max:    math.Inf(-1),
+	}
+	for _, v := range values {
+		history.Min = math.Min(history.Min, v)
+		history.Max = math.Max(history.Max, v)
+	}
+
+	for i := len(series) - 1; i > 0; i-- {
+		diff := series[i] - series[i-1]
+		if diff == 0 || (history.Streak > 0 && diff < 0) || (history.Streak < 0 && diff > 0) {
+			break
+		}
+		if diff > 0 {
+			history.Streak++
+		} else {
+			history.Streak--
+		}
+	}
+
+	if ref != nil {
+		history.Cross = newHistoryCross(series, ref)
+	}
+
+	h.entries[name] = history
+}
+
+// newHistoryCross finds the latest cross of series over or under ref.
+func newHistoryCross(series []float64, ref *reference) *HistoryCross {
+	latest, _ := ref.at(0)
+	above := series[len(series)-1] > latest
+	cross := &HistoryCross{Reference: ref.name, Position: "below", BarsSince: -1}
+	if above {
+		cross.Position = "above"
+	}
+	for i := 1; i < len(series); i++ {
+		value, ok := ref.at(i)
+		if !ok {
+			break
+		}
+		if (series[len(series)-1-i] > value) != above {
+			cross.BarsSince = i - 1
+			break
+		}
+	}
+	return cross
+}
+
+// result returns the collected histories, or nil if there are none.
+func (h *historyBuilder) result() map[string]*IndicatorHistory {
+	if len(h.entries) == 0 {
+		return nil
+	}
+	return h.entries
+}
@@ -0,0 +1,104 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHistoryBuilder(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  int
+		forming int
+		series  []float64
+		ref     *reference
+		want    *IndicatorHistory
+	}{
+		{
+			name:   "window and rising streak",
+			window: 3,
+			series: []float64{5, 4, 1, 2, 3},
+			want:   &IndicatorHistory{Values: []float64{1, 2, 3}, Slope: 1, Min: 1, Max: 3, Streak: 2},
+		},
+		{
+			name:    "forming value dropped",
+			window:  3,
+			forming: 1,
+			series:  []float64{5, 4, 1, 2, 3, 100},
+			want:    &IndicatorHistory{Values: []float64{1, 2, 3}, Slope: 1, Min: 1, Max: 3, Streak: 2},
+		},
+		{
+			name:   "falling streak stops at a flat candle",
+			window: 2,
+			series: []float64{1, 3, 3, 2, 1},
+			want:   &IndicatorHistory{Values: []float64{2, 1}, Slope: -1, Min: 1, Max: 2, Streak: -2},
+		},
+		{
+			name:   "window longer than series",
+			window: 10,
+			series: []float64{2, 2},
+			want:   &IndicatorHistory{Values: []float64{2, 2}, Min: 2, Max: 2},
+		},
+		{
+			name:   "level cross",
+			window: 2,
+			series: []float64{60, 40, 45, 55, 58},
+			ref:    level("50", 50),
+			want: &IndicatorHistory{Values: []float64{55, 58}, Slope: 3, Min: 55, Max: 58, Streak: 3,
+				Cross: &HistoryCross{Reference: "50", Position: "above", BarsSince: 1}},
+		},
+		{
+			name:   "no cross within the series",
+			window: 1,
+			series: []float64{10, 20, 30},
+			ref:    level("0", 0),
+			want: &IndicatorHistory{Values: []float64{30}, Min: 30, Max: 30, Streak: 2,
+				Cross: &HistoryCross{Reference: "0", Position: "above", BarsSince: -1}},
+		},
+		{
+			name:    "line cross with a shorter reference and a forming value",
+			window:  1,
+			forming: 1,
+			series:  []float64{1, 1, 5, 2, 9},
+			ref:     &reference{name: "signal", values: []float64{3, 3, 3, 0}},
+			want: &IndicatorHistory{Values: []float64{2}, Min: 2, Max: 2, Streak: -1,
+				Cross: &HistoryCross{Reference: "signal", Position: "below", BarsSince: 0}},
+		},
+		{
+			name:    "single reference value after trimming is not a level",
+			window:  1,
+			forming: 1,
+			series:  []float64{1, 2, 3, 4},
+			ref:     &reference{name: "signal", values: []float64{0, 9}},
+			want: &IndicatorHistory{Values: []float64{3}, Min: 3, Max: 3, Streak: 2,
+				Cross: &HistoryCross{Reference: "signal", Position: "above", BarsSince: -1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHistoryBuilder(tt.window, tt.forming)
+			h.add("x", tt.series, tt.ref)
+			if got := h.result()["x"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v (cross %+v), want %+v (cross %+v)", got, got.Cross, tt.want, tt.want.Cross)
+			}
+		})
+	}
+}
+
+func TestHistoryBuilderDisabled(t *testing.T) {
+	for _, h := range []*historyBuilder{newHistoryBuilder(0, 0), newHistoryBuilder(5, 3)} {
+		h.add("x", []float64{1, 2, 3}, nil)
+		if got := h.result(); got != nil {
+			t.Errorf("got %v, want no history", got)
+		}
+	}
+}
+
+func TestNewServiceClampsHistoryWindow(t *testing.T) {
+	for window, want := range map[int]int{-1: 0, 0: 0, 10: 10, MaxHistoryWindow + 1: MaxHistoryWindow} {
+		if got := NewService(window).historyWindow; got != want {
+			t.Errorf("NewService(%d) keeps %d values, want %d", window, got, want)
+		}
+	}
+}
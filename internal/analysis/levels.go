@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"tv-bot-go/internal/binance"
+	"tv-bot-go/pkg/indicators"
+	"tv-bot-go/pkg/levels"
+)
+
+const (
+	// maxZones is the number of top-ranked support/resistance zones kept.
+	maxZones = 5
+	// nearestLevels is the number of levels reported on each side of price.
+	nearestLevels = 3
+)
+
+// Levels holds pivot points, support/resistance zones and the levels closest to price.
+type Levels struct {
+	Price  float64              `json:"price"`
+	Daily  []levels.PivotPoints `json:"daily_pivots,omitempty"`
+	Weekly []levels.PivotPoints `json:"weekly_pivots,omitempty"`
+	Zones  []levels.Zone        `json:"zones,omitempty"`
+	Above  []levels.Level       `json:"nearest_above,omitempty"`
+	Below  []levels.Level       `json:"nearest_below,omitempty"`
+}
+
+// AnalyzeLevels calculates pivot points from the previous completed daily and
+// weekly candles and clusters the swings of klines into support/resistance zones.
+func (s *Service) AnalyzeLevels(klines, daily, weekly []binance.Kline) *Levels {
+	if len(klines) == 0 {
+		return nil
+	}
+
+	price := klines[len(klines)-1].Close
+	result := &Levels{Price: price}
+	var candidates []levels.Level
+
+	for _, period := range []struct {
+		name   string
+		klines []binance.Kline
+		pivots *[]levels.PivotPoints
+	}{
+		{"daily", daily, &result.Daily},
+		{"weekly", weekly, &result.Weekly},
+	} {
+		closed := closedKlines(period.klines)
+		if len(closed) == 0 {
+			continue
+		}
+		prev := closed[len(closed)-1]
+		for _, method := range levels.PivotMethods {
+			pivots := levels.CalculatePivotPoints(prev, method)
+			*period.pivots = append(*period.pivots, pivots)
+			candidates = append(candidates, pivots.Levels(period.name)...)
+		}
+	}
+
+	// Swings within half an ATR of each other are treated as the same zone.
+	tolerance := price * 0.002
+	if atr := indicators.CalculateATR(getSlice(klines, "high"), getSlice(klines, "low"), getSlice(klines, "close"), 14); len(atr) > 0 {
+		tolerance = 0.5 * atr[len(atr)-1]
+	}
+	zones := levels.ClusterZones(closedKlines(klines), 3, 3, tolerance)
+	if len(zones) > maxZones {
+		zones = zones[:maxZones]
+	}
+	result.Zones = zones
+	for _, z := range zones {
+		candidates = append(candidates, z.Level())
+	}
+
+	result.Above, result.Below = levels.Nearest(candidates, price, nearestLevels)
+	return result
+}
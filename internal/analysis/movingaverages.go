@@ -0,0 +1,74 @@
+package analysis
+
+import "tv-bot-go/pkg/indicators"
+
+// movingAveragePeriods are the SMA periods price is compared against.
+var movingAveragePeriods = []int{20, 50, 200}
+
+// MovingAverages holds price position versus key SMAs and the 50/200 cross state.
+type MovingAverages struct {
+	Averages []MAPosition `json:"averages"`
+	Cross    *MACross     `json:"cross,omitempty"`
+}
+
+// MAPosition describes where the latest close sits relative to a moving average.
+type MAPosition struct {
+	Period          int     `json:"period"`
+	Value           float64 `json:"value"`
+	DistancePercent float64 `json:"distance_percent"`
+	Above           bool    `json:"above"`
+}
+
+// MACross describes the relationship between the 50 and 200 period SMAs.
+type MACross struct {
+	// Status is "golden" when the 50 SMA is above the 200 SMA and "death" otherwise.
+	Status string `json:"status"`
+	// BarsSinceCross is the number of candles since the last cross, or -1 if
+	// no cross happened within the analyzed window.
+	BarsSinceCross int `json:"bars_since_cross"`
+}
+
+// analyzeMovingAverages compares the latest close with the 20/50/200 SMAs and
+// reports the golden/death cross state when enough data is available.
+func analyzeMovingAverages(closes []float64) *MovingAverages {
+	price := closes[len(closes)-1]
+	result := &MovingAverages{}
+	series := make(map[int][]float64, len(movingAveragePeriods))
+
+	for _, period := range movingAveragePeriods {
+		sma := indicators.CalculateSMA(closes, period)
+		if len(sma) == 0 {
+			continue
+		}
+		series[period] = sma
+		value := sma[len(sma)-1]
+		position := MAPosition{Period: period, Value: value, Above: price > value}
+		if value != 0 {
+			position.DistancePercent = 100 * (price - value) / value
+		}
+		result.Averages = append(result.Averages, position)
+	}
+	if len(result.Averages) == 0 {
+		return nil
+	}
+
+	fast, slow := series[50], series[200]
+	if len(slow) == 0 {
+		return result
+	}
+	// Align the 50 SMA with the shorter 200 SMA.
+	fast = fast[len(fast)-len(slow):]
+	last := len(slow) - 1
+	result.Cross = &MACross{Status: "death", BarsSinceCross: -1}
+	if fast[last] > slow[last] {
+		result.Cross.Status = "golden"
+	}
+	for i := last; i > 0; i-- {
+		if (fast[i] > slow[i]) != (fast[i-1] > slow[i-1]) {
+			result.Cross.BarsSinceCross = last - i
+			break
+		}
+	}
+
+	return result
+}
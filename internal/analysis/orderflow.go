@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"tv-bot-go/internal/binance"
+	"tv-bot-go/pkg/indicators"
+)
+
+// orderFlowWindow is the number of closed candles summarized by the order flow averages.
+const orderFlowWindow = 20
+
+// OrderFlow summarizes aggressive buying and selling from taker volume.
+type OrderFlow struct {
+	// Delta is the taker buy minus taker sell volume of the latest closed candle.
+	Delta float64 `json:"delta"`
+	// CVD is the cumulative volume delta over the analyzed window.
+	CVD float64 `json:"cvd"`
+	// CVDChange is the change in CVD over the last orderFlowWindow candles.
+	CVDChange float64 `json:"cvd_change"`
+	// PriceChangePercent is the price change over the same candles.
+	PriceChangePercent float64 `json:"price_change_percent"`
+	TakerBuyRatio      float64 `json:"taker_buy_ratio"`
+	AvgTakerBuyRatio   float64 `json:"avg_taker_buy_ratio"`
+	// Pressure is "aggressive_buying", "aggressive_selling" or "balanced".
+	Pressure    string                  `json:"pressure"`
+	Divergences []indicators.Divergence `json:"cvd_divergences,omitempty"`
+}
+
+// analyzeOrderFlow calculates order flow metrics on closed klines.
+func analyzeOrderFlow(klines []binance.Kline) *OrderFlow {
+	klines = closedKlines(klines)
+	if len(klines) < 2 {
+		return nil
+	}
+
+	last := len(klines) - 1
+	start := max(last-orderFlowWindow, 0)
+	delta := indicators.CalculateVolumeDelta(klines)
+	cvd := indicators.CalculateCVD(klines)
+	ratio := indicators.CalculateTakerBuyRatio(klines)
+
+	flow := &OrderFlow{
+		Delta:         delta[last],
+		CVD:           cvd[last],
+		CVDChange:     cvd[last] - cvd[start],
+		TakerBuyRatio: ratio[last],
+	}
+	if base := klines[start].Close; base != 0 {
+		flow.PriceChangePercent = 100 * (klines[last].Close - base) / base
+	}
+	for _, r := range ratio[start+1:] {
+		flow.AvgTakerBuyRatio += r
+	}
+	flow.AvgTakerBuyRatio /= float64(last - start)
+
+	switch {
+	case flow.AvgTakerBuyRatio >= 0.52:
+		flow.Pressure = "aggressive_buying"
+	case flow.AvgTakerBuyRatio <= 0.48:
+		flow.Pressure = "aggressive_selling"
+	default:
+		flow.Pressure = "balanced"
+	}
+
+	flow.Divergences = recentDivergences(indicators.FindDivergences("cvd",
+		getSlice(klines, "high"), getSlice(klines, "low"), cvd, indicators.DefaultDivergenceConfig))
+
+	return flow
+}
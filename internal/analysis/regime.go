@@ -0,0 +1,118 @@
+package analysis
+
+import (
+	"math"
+	"tv-bot-go/pkg/indicators"
+)
+
+// Market regime labels.
+const (
+	RegimeTrendingUp     = "trending_up"
+	RegimeTrendingDown   = "trending_down"
+	RegimeRanging        = "ranging"
+	RegimeHighVolatility = "high_volatility"
+)
+
+const (
+	// regimeWindow is the number of recent candles the ATR and bandwidth
+	// percentiles are ranked against.
+	regimeWindow = 100
+	// regimeSlopeBars is the distance over which the moving-average slope is measured.
+	regimeSlopeBars = 10
+	// regimeTrendADX is the ADX level above which a market is considered trending.
+	regimeTrendADX = 25
+)
+
+// Regime classifies the market state of a timeframe.
+type Regime struct {
+	Label string `json:"label"`
+	// Confidence is a score between 0 and 1 describing how clearly the
+	// indicators agree on the label.
+	Confidence float64 `json:"confidence"`
+	ADX        float64 `json:"adx"`
+	// ATRPercentile and BandwidthPercentile rank the latest ATR and Bollinger
+	// bandwidth against the recent window, from 0 to 100.
+	ATRPercentile       float64 `json:"atr_percentile"`
+	BandwidthPercentile float64 `json:"bandwidth_percentile"`
+	// MASlope is the change of the 50 SMA over the slope window, in ATRs.
+	MASlope float64 `json:"ma_slope"`
+}
+
+// analyzeRegime combines trend strength, volatility and moving-average slope
+// into a single regime label.
+func analyzeRegime(highs, lows, closes []float64) *Regime {
+	adx := indicators.CalculateADX(highs, lows, closes, 14)
+	atr := indicators.CalculateATR(highs, lows, closes, 14)
+	sma := indicators.CalculateSMA(closes, 50)
+	if len(adx) == 0 || len(atr) == 0 || len(sma) <= regimeSlopeBars {
+		return nil
+	}
+
+	bb := indicators.CalculateBollingerBands(closes, 20, 2)
+	bandwidth := make([]float64, len(bb))
+	for i, b := range bb {
+		bandwidth[i] = b.Bandwidth
+	}
+	// ATR is compared as a percentage of price so that long trends do not
+	// inflate its percentile.
+	atrPercent := make([]float64, len(atr))
+	offset := len(closes) - len(atr)
+	for i, v := range atr {
+		if c := closes[offset+i]; c != 0 {
+			atrPercent[i] = v / c
+		}
+	}
+
+	regime := &Regime{
+		ADX:                 adx[len(adx)-1],
+		ATRPercentile:       percentileRank(atrPercent, regimeWindow),
+		BandwidthPercentile: percentileRank(bandwidth, regimeWindow),
+	}
+	if latestATR := atr[len(atr)-1]; latestATR != 0 {
+		regime.MASlope = (sma[len(sma)-1] - sma[len(sma)-1-regimeSlopeBars]) / latestATR
+	}
+
+	// Trend strength grows from 0 at ADX 15 to 1 at ADX 40; a slope of one
+	// ATR over the slope window counts as a full-strength move.
+	strength := clamp((regime.ADX-(regimeTrendADX-10))/25, 0, 1)
+	slope := clamp(math.Abs(regime.MASlope), 0, 1)
+	trendScore := 0.6*strength + 0.4*slope
+	rangeScore := 0.6*(1-strength) + 0.4*(1-slope)
+	volatilityScore := (regime.ATRPercentile + regime.BandwidthPercentile) / 200
+
+	switch {
+	case volatilityScore >= 0.85 && volatilityScore > trendScore:
+		regime.Label, regime.Confidence = RegimeHighVolatility, volatilityScore
+	case trendScore > rangeScore && regime.MASlope > 0:
+		regime.Label, regime.Confidence = RegimeTrendingUp, trendScore
+	case trendScore > rangeScore && regime.MASlope < 0:
+		regime.Label, regime.Confidence = RegimeTrendingDown, trendScore
+	default:
+		regime.Label, regime.Confidence = RegimeRanging, rangeScore
+	}
+	regime.Confidence = math.Round(regime.Confidence*100) / 100
+
+	return regime
+}
+
+// percentileRank returns the percentage of the last window values that are
+// at or below the latest value.
+func percentileRank(values []float64, window int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	recent := values[max(len(values)-window, 0):]
+	latest := recent[len(recent)-1]
+	below := 0
+	for _, v := range recent {
+		if v <= latest {
+			below++
+		}
+	}
+	return 100 * float64(below) / float64(len(recent))
+}
+
+// clamp limits v to the range [lo, hi].
+func clamp(v, lo, hi float64) float64 {
+	return math.Min(math.Max(v, lo), hi)
+}
@@ -0,0 +1,81 @@
+package analysis
+
+import (
+	"tv-bot-go/internal/binance"
+	"tv-bot-go/pkg/stats"
+)
+
+const (
+	// statisticsWindow is the number of recent closed candles the volatility
+	// and distribution statistics are calculated over.
+	statisticsWindow = 100
+	// zScoreWindow is the window the return and price z-scores are measured against.
+	zScoreWindow = 20
+	// hurstMargin is the distance from 0.5 within which the Hurst exponent is
+	// read as a random walk.
+	hurstMargin = 0.05
+)
+
+// Statistics holds statistical features of the returns of a timeframe.
+// Volatilities are per-candle standard deviations of log returns, in percent.
+type Statistics struct {
+	Window                int     `json:"window"`
+	RealizedVolatility    float64 `json:"realized_volatility"`
+	ParkinsonVolatility   float64 `json:"parkinson_volatility"`
+	GarmanKlassVolatility float64 `json:"garman_klass_volatility"`
+	// AnnualizedVolatility is the realized volatility scaled to one year.
+	AnnualizedVolatility float64 `json:"annualized_volatility"`
+	// ReturnZScore and PriceZScore compare the latest return and close with
+	// the last zScoreWindow candles.
+	ReturnZScore float64 `json:"return_z_score"`
+	PriceZScore  float64 `json:"price_z_score"`
+	Skewness     float64 `json:"skewness"`
+	// Kurtosis is the excess kurtosis; positive values mean fat tails.
+	Kurtosis float64 `json:"kurtosis"`
+	// Autocorrelation is the lag-1 autocorrelation of returns.
+	Autocorrelation float64 `json:"autocorrelation"`
+	Hurst           float64 `json:"hurst,omitempty"`
+	// HurstRegime is "trending", "mean_reverting" or "random_walk".
+	HurstRegime string `json:"hurst_regime,omitempty"`
+}
+
+// analyzeStatistics calculates return statistics over the recent closed candles.
+// The Hurst exponent uses every closed candle, as it needs a long series.
+func analyzeStatistics(klines []binance.Kline) *Statistics {
+	klines = closedKlines(klines)
+	if len(klines) <= zScoreWindow {
+		return nil
+	}
+
+	closes := getSlice(klines, "close")
+	returns := stats.LogReturns(closes)
+	recent := klines[max(len(klines)-statisticsWindow-1, 0):]
+	recentReturns := returns[max(len(returns)-statisticsWindow, 0):]
+
+	result := &Statistics{
+		Window:                len(recentReturns),
+		RealizedVolatility:    100 * stats.RealizedVolatility(recent),
+		ParkinsonVolatility:   100 * stats.ParkinsonVolatility(recent[1:]),
+		GarmanKlassVolatility: 100 * stats.GarmanKlassVolatility(recent[1:]),
+		ReturnZScore:          stats.ZScore(returns, zScoreWindow),
+		PriceZScore:           stats.ZScore(closes, zScoreWindow),
+		Skewness:              stats.Skewness(recentReturns),
+		Kurtosis:              stats.Kurtosis(recentReturns),
+		Autocorrelation:       stats.Autocorrelation(recentReturns, 1),
+		Hurst:                 stats.Hurst(returns),
+	}
+	result.AnnualizedVolatility = stats.Annualize(result.RealizedVolatility, stats.BarDuration(recent))
+
+	if result.Hurst != 0 {
+		switch {
+		case result.Hurst > 0.5+hurstMargin:
+			result.HurstRegime = "trending"
+		case result.Hurst < 0.5-hurstMargin:
+			result.HurstRegime = "mean_reverting"
+		default:
+			result.HurstRegime = "random_walk"
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,150 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"tv-bot-go/internal/binance"
+	"tv-bot-go/pkg/correlation"
+	"tv-bot-go/pkg/stats"
+)
+
+// StrengthBenchmarks are the majors relative strength is measured against.
+var StrengthBenchmarks = []string{"BTCUSDT", "ETHUSDT"}
+
+// strengthTrendThreshold is the fitted ratio move, in percent over the
+// lookback, below which the relative strength trend is considered flat.
+const strengthTrendThreshold = 1.0
+
+// RelativeStrength describes a symbol's price ratio to a benchmark over one lookback.
+type RelativeStrength struct {
+	Benchmark string `json:"benchmark"`
+	Lookback  int    `json:"lookback"`
+	// Ratio is the latest symbol/benchmark price ratio.
+	Ratio float64 `json:"ratio"`
+	// ChangePercent is the change of the ratio over the lookback.
+	ChangePercent float64 `json:"change_percent"`
+	// Percentile ranks the latest ratio within the lookback, from 0 to 100.
+	Percentile float64 `json:"percentile"`
+	// Trend is "rising", "falling" or "flat", based on a linear fit of the ratio.
+	Trend string `json:"trend"`
+}
+
+// StrengthScore ranks a symbol by its relative strength against the benchmarks.
+type StrengthScore struct {
+	Symbol string `json:"symbol"`
+	// Score is the mean ratio change across all benchmarks and lookbacks.
+	Score   float64            `json:"score"`
+	Against []RelativeStrength `json:"against"`
+}
+
+// RelativeStrengthSeries returns the closed-candle price ratio of symbol to
+// benchmark on their common open times.
+func RelativeStrengthSeries(symbol, benchmark []binance.Kline) []float64 {
+	a, b := correlation.AlignPair(closedKlines(symbol), closedKlines(benchmark))
+	ratio := make([]float64, len(a))
+	for i := range a {
+		if b[i] != 0 {
+			ratio[i] = a[i] / b[i]
+		}
+	}
+	return ratio
+}
+
+// newRelativeStrength summarizes the last lookback+1 values of a ratio series.
+func newRelativeStrength(benchmark string, ratio []float64, lookback int) RelativeStrength {
+	recent := ratio[len(ratio)-lookback-1:]
+	latest := recent[len(recent)-1]
+	rs := RelativeStrength{
+		Benchmark:  benchmark,
+		Lookback:   lookback,
+		Ratio:      latest,
+		Percentile: percentileRank(recent, len(recent)),
+		Trend:      "flat",
+	}
+	if recent[0] != 0 {
+		rs.ChangePercent = 100 * (latest - recent[0]) / recent[0]
+	}
+	if avg := stats.Mean(recent); avg != 0 {
+		switch fitted := 100 * linearSlope(recent) * float64(lookback) / avg; {
+		case fitted > strengthTrendThreshold:
+			rs.Trend = "rising"
+		case fitted < -strengthTrendThreshold:
+			rs.Trend = "falling"
+		}
+	}
+	return rs
+}
+
+// RankStrength scores every symbol in klines against the benchmarks over each
+// lookback, in candles, and returns them from the strongest to the weakest.
+// Benchmarks are only compared with each other, and symbols without enough
+// shared history for the longest lookback are skipped.
+func (s *Service) RankStrength(klines map[string][]binance.Kline, lookbacks []int) ([]StrengthScore, error) {
+	if len(lookbacks) == 0 {
+		return nil, fmt.Errorf("no lookbacks given")
+	}
+	longest := 0
+	for _, lookback := range lookbacks {
+		if lookback < 1 {
+			return nil, fmt.Errorf("invalid lookback %d", lookback)
+		}
+		longest = max(longest, lookback)
+	}
+	for _, benchmark := range StrengthBenchmarks {
+		if _, ok := klines[benchmark]; !ok {
+			return nil, fmt.Errorf("no klines for benchmark %s", benchmark)
+		}
+	}
+
+	var scores []StrengthScore
+	for symbol, k := range klines {
+		score := StrengthScore{Symbol: symbol}
+		for _, benchmark := range StrengthBenchmarks {
+			if benchmark == symbol {
+				continue
+			}
+			ratio := RelativeStrengthSeries(k, klines[benchmark])
+			if len(ratio) <= longest {
+				score.Against = nil
+				break
+			}
+			for _, lookback := range lookbacks {
+				rs := newRelativeStrength(benchmark, ratio, lookback)
+				score.Against = append(score.Against, rs)
+				score.Score += rs.ChangePercent
+			}
+		}
+		if len(score.Against) == 0 {
+			continue
+		}
+		score.Score /= float64(len(score.Against))
+		scores = append(scores, score)
+	}
+	if len(scores) == 0 {
+		return nil, fmt.Errorf("no symbol has %d candles of history shared with the benchmarks", longest+1)
+	}
+
+	sort.Slice(scores, func(a, b int) bool {
+		if scores[a].Score != scores[b].Score {
+			return scores[a].Score > scores[b].Score
+		}
+		return scores[a].Symbol < scores[b].Symbol
+	})
+	return scores, nil
+}
+
+// linearSlope returns the least-squares slope of values against their index.
+func linearSlope(values []float64) float64 {
+	n := float64(len(values))
+	if n < 2 {
+		return 0
+	}
+	meanX, meanY := (n-1)/2, stats.Mean(values)
+	var cov, varX float64
+	for i, v := range values {
+		dx := float64(i) - meanX
+		cov += dx * (v - meanY)
+		varX += dx * dx
+	}
+	return cov / varX
+}
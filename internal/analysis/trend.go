@@ -0,0 +1,40 @@
+package analysis
+
+import "tv-bot-go/pkg/indicators"
+
+// TrendStopState describes the latest state of a trailing-stop trend indicator.
+type TrendStopState struct {
+	Value float64 `json:"value"`
+	// Direction is "up" when the stop trails below price and "down" when it trails above.
+	Direction       string                `json:"direction"`
+	DistancePercent float64               `json:"distance_percent"`
+	LastFlip        *indicators.TrendFlip `json:"last_flip,omitempty"`
+	// BarsSinceFlip is the number of candles since the last flip, or -1 if
+	// the direction did not flip within the analyzed series.
+	BarsSinceFlip int `json:"bars_since_flip"`
+}
+
+// newTrendStopState summarizes a trend stop series against the latest close.
+func newTrendStopState(stop *indicators.TrendStop, closes []float64) *TrendStopState {
+	if stop == nil {
+		return nil
+	}
+	last := len(stop.Values) - 1
+	if last < 0 || stop.Directions[last] == 0 {
+		return nil
+	}
+
+	state := &TrendStopState{Value: stop.Values[last], Direction: "down", BarsSinceFlip: -1}
+	if stop.Directions[last] == 1 {
+		state.Direction = "up"
+	}
+	if price := closes[last]; price != 0 {
+		state.DistancePercent = 100 * (price - state.Value) / price
+	}
+	if flip := stop.LastFlip(); flip != nil {
+		state.LastFlip = flip
+		state.BarsSinceFlip = last - flip.Index
+	}
+
+	return state
+}
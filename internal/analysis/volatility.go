@@ -0,0 +1,39 @@
+package analysis
+
+import "tv-bot-go/pkg/indicators"
+
+// Volatility holds the volatility indicators for a timeframe.
+type Volatility struct {
+	ATR        float64                    `json:"atr"`
+	ATRPercent float64                    `json:"atr_percent"`
+	Bollinger  *indicators.BollingerBands `json:"bollinger,omitempty"`
+	Keltner    *indicators.KeltnerChannel `json:"keltner,omitempty"`
+	Squeeze    *indicators.SqueezeState   `json:"squeeze,omitempty"`
+}
+
+// analyzeVolatility calculates ATR, Bollinger Bands, Keltner Channels and the squeeze state.
+func analyzeVolatility(highs, lows, closes []float64) *Volatility {
+	atrResult := indicators.CalculateATR(highs, lows, closes, 14)
+	if len(atrResult) == 0 {
+		return nil
+	}
+
+	volatility := &Volatility{ATR: atrResult[len(atrResult)-1]}
+	if lastClose := closes[len(closes)-1]; lastClose != 0 {
+		volatility.ATRPercent = 100 * volatility.ATR / lastClose
+	}
+
+	bbResult := indicators.CalculateBollingerBands(closes, 20, 2)
+	if len(bbResult) > 0 {
+		volatility.Bollinger = &bbResult[len(bbResult)-1]
+	}
+
+	kcResult := indicators.CalculateKeltnerChannels(highs, lows, closes, 20, 10, 1.5)
+	if len(kcResult) > 0 {
+		volatility.Keltner = &kcResult[len(kcResult)-1]
+	}
+
+	volatility.Squeeze = indicators.LatestSqueezeState(indicators.CalculateSqueeze(bbResult, kcResult))
+
+	return volatility
+}
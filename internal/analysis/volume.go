@@ -0,0 +1,103 @@
+package analysis
+
+import (
+	"tv-bot-go/internal/binance"
+	"tv-bot-go/pkg/indicators"
+	"tv-bot-go/pkg/stats"
+)
+
+const (
+	// rvolPeriod is the number of preceding candles relative volume is measured against.
+	rvolPeriod = 20
+	// highRVOL, extremeRVOL and lowRVOL are the relative volume thresholds of
+	// the volume status.
+	highRVOL    = 2.0
+	extremeRVOL = 3.0
+	lowRVOL     = 0.5
+)
+
+// VolumeHealth summarizes volume-based indicators on the latest closed candle.
+type VolumeHealth struct {
+	// RVOL is the volume of the latest closed candle relative to the average
+	// of the preceding candles.
+	RVOL float64 `json:"rvol"`
+	// ZScore compares the latest closed volume with the last candles, including itself.
+	ZScore float64 `json:"z_score"`
+	// Status is "extreme", "high", "normal" or "low", based on RVOL.
+	Status string `json:"status"`
+	// Abnormal is true when the status is not normal.
+	Abnormal bool `json:"abnormal"`
+	// Candle is the direction of the latest closed candle ("up", "down" or "flat").
+	Candle string `json:"candle"`
+	// ADTrend is the direction of the Accumulation/Distribution line over the
+	// RVOL period ("rising", "falling" or "flat").
+	ADTrend          string  `json:"ad_trend"`
+	CMF              float64 `json:"cmf"`
+	ChaikinOsc       float64 `json:"chaikin_oscillator"`
+	ForceIndex       float64 `json:"force_index"`
+	EaseOfMovement   float64 `json:"ease_of_movement"`
+	VolumeOscillator float64 `json:"volume_oscillator"`
+}
+
+// analyzeVolume calculates the volume indicators on closed candles and flags
+// abnormal volume on the latest one.
+func analyzeVolume(klines []binance.Kline) *VolumeHealth {
+	klines = closedKlines(klines)
+	highs, lows := getSlice(klines, "high"), getSlice(klines, "low")
+	closes, volumes := getSlice(klines, "close"), getSlice(klines, "volume")
+
+	rvol := indicators.CalculateRVOL(volumes, rvolPeriod)
+	if len(rvol) == 0 {
+		return nil
+	}
+
+	last := klines[len(klines)-1]
+	health := &VolumeHealth{
+		RVOL:    rvol[len(rvol)-1],
+		ZScore:  stats.ZScore(volumes, rvolPeriod+1),
+		Status:  "normal",
+		Candle:  "flat",
+		ADTrend: "flat",
+	}
+	switch {
+	case health.RVOL >= extremeRVOL:
+		health.Status = "extreme"
+	case health.RVOL >= highRVOL:
+		health.Status = "high"
+	case health.RVOL <= lowRVOL:
+		health.Status = "low"
+	}
+	health.Abnormal = health.Status != "normal"
+	switch {
+	case last.Close > last.Open:
+		health.Candle = "up"
+	case last.Close < last.Open:
+		health.Candle = "down"
+	}
+
+	ad := indicators.CalculateAD(highs, lows, closes, volumes)
+	switch change := ad[len(ad)-1] - ad[len(ad)-1-rvolPeriod]; {
+	case change > 0:
+		health.ADTrend = "rising"
+	case change < 0:
+		health.ADTrend = "falling"
+	}
+
+	if cmf := indicators.CalculateCMF(highs, lows, closes, volumes, 20); len(cmf) > 0 {
+		health.CMF = cmf[len(cmf)-1]
+	}
+	if osc := indicators.CalculateChaikinOscillator(highs, lows, closes, volumes, 3, 10); len(osc) > 0 {
+		health.ChaikinOsc = osc[len(osc)-1]
+	}
+	if force := indicators.CalculateForceIndex(closes, volumes, 13); len(force) > 0 {
+		health.ForceIndex = force[len(force)-1]
+	}
+	if eom := indicators.CalculateEaseOfMovement(highs, lows, volumes, 14, 1e6); len(eom) > 0 {
+		health.EaseOfMovement = eom[len(eom)-1]
+	}
+	if vo := indicators.CalculateVolumeOscillator(volumes, 5, 10); len(vo) > 0 {
+		health.VolumeOscillator = vo[len(vo)-1]
+	}
+
+	return health
+}
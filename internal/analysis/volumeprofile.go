@@ -0,0 +1,44 @@
+package analysis
+
+import (
+	"tv-bot-go/internal/binance"
+	"tv-bot-go/pkg/indicators"
+)
+
+const (
+	// volumeProfileBins is the number of price bins in the volume profile.
+	volumeProfileBins = 24
+	// valueAreaShare is the fraction of volume contained in the value area.
+	valueAreaShare = 0.7
+)
+
+// VolumeProfile is the volume profile of the analyzed window and where price sits in it.
+type VolumeProfile struct {
+	*indicators.VolumeProfile
+	// Position is "above_value_area", "inside_value_area" or "below_value_area".
+	Position string `json:"position"`
+	// POCDistancePercent is the distance from the latest close to the point of control.
+	POCDistancePercent float64 `json:"poc_distance_percent"`
+}
+
+// analyzeVolumeProfile builds the volume profile over the whole kline window.
+func analyzeVolumeProfile(klines []binance.Kline) *VolumeProfile {
+	profile := indicators.CalculateVolumeProfile(klines, volumeProfileBins, valueAreaShare)
+	if profile == nil {
+		return nil
+	}
+
+	price := klines[len(klines)-1].Close
+	result := &VolumeProfile{VolumeProfile: profile, Position: "inside_value_area"}
+	switch {
+	case price > profile.ValueAreaHigh:
+		result.Position = "above_value_area"
+	case price < profile.ValueAreaLow:
+		result.Position = "below_value_area"
+	}
+	if price != 0 {
+		result.POCDistancePercent = 100 * (price - profile.POC) / price
+	}
+
+	return result
+}
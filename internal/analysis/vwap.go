@@ -0,0 +1,71 @@
+package analysis
+
+import (
+	"tv-bot-go/internal/binance"
+	"tv-bot-go/pkg/indicators"
+)
+
+// VWAPAnalysis holds the session and anchored VWAPs for a timeframe.
+type VWAPAnalysis struct {
+	Daily    *VWAPPosition `json:"daily,omitempty"`
+	Weekly   *VWAPPosition `json:"weekly,omitempty"`
+	Anchor   int64         `json:"anchor,omitempty"`
+	Anchored *VWAPPosition `json:"anchored,omitempty"`
+}
+
+// VWAPPosition describes where the latest close sits relative to a VWAP and its bands.
+type VWAPPosition struct {
+	indicators.VWAPBands
+	DistancePercent float64 `json:"distance_percent"`
+	// Position is one of above_2sd, above_1sd, above, below, below_1sd or below_2sd.
+	Position string `json:"position"`
+	// Partial is set when the klines start after the session began, so the
+	// VWAP misses the session's earliest trades.
+	Partial bool `json:"partial,omitempty"`
+}
+
+// analyzeVWAP calculates the daily and weekly session VWAPs.
+func analyzeVWAP(klines []binance.Kline) *VWAPAnalysis {
+	return &VWAPAnalysis{
+		Daily:  sessionVWAP(klines, indicators.DailySession),
+		Weekly: sessionVWAP(klines, indicators.WeeklySession),
+	}
+}
+
+// sessionVWAP positions the latest close against the VWAP of the current
+// session, flagging it as partial if the klines start after the session did.
+func sessionVWAP(klines []binance.Kline, session indicators.VWAPSession) *VWAPPosition {
+	result := indicators.CalculateSessionVWAP(klines, session)
+	if len(result) == 0 {
+		return nil
+	}
+	last := klines[len(klines)-1]
+	position := newVWAPPosition(result[len(result)-1], last.Close)
+	position.Partial = klines[0].OpenTime > indicators.SessionStart(last.OpenTime, session)
+	return position
+}
+
+// newVWAPPosition classifies price against a VWAP and its standard deviation bands.
+func newVWAPPosition(bands indicators.VWAPBands, price float64) *VWAPPosition {
+	position := &VWAPPosition{VWAPBands: bands}
+	if bands.VWAP != 0 {
+		position.DistancePercent = 100 * (price - bands.VWAP) / bands.VWAP
+	}
+
+	switch {
+	case price >= bands.Upper2 && bands.StdDev > 0:
+		position.Position = "above_2sd"
+	case price >= bands.Upper1 && bands.StdDev > 0:
+		position.Position = "above_1sd"
+	case price >= bands.VWAP:
+		position.Position = "above"
+	case price <= bands.Lower2 && bands.StdDev > 0:
+		position.Position = "below_2sd"
+	case price <= bands.Lower1 && bands.StdDev > 0:
+		position.Position = "below_1sd"
+	default:
+		position.Position = "below"
+	}
+
+	return position
+}
@@ -7,11 +7,13 @@ import (
 	"net/http"
 	"strconv"
 	"time"
+	"tv-bot-go/pkg/kline"
 )
 
 const (
-	defaultBaseURL = "https://api.binance.com"
-	klinesEndpoint = "/api/v3/klines"
+	defaultBaseURL       = "https://api.binance.com"
+	klinesEndpoint       = "/api/v3/klines"
+	exchangeInfoEndpoint = "/api/v3/exchangeInfo"
 )
 
 // Client is a Binance API client.
@@ -29,20 +31,14 @@ func NewClient() *Client {
 }
 
 // Kline represents a single kline/candlestick.
-type Kline struct {
-	OpenTime                 int64
-	Open, High, Low, Close   float64
-	Volume                   float64
-	CloseTime                int64
-	QuoteAssetVolume         float64
-	NumberOfTrades           int64
-	TakerBuyBaseAssetVolume  float64
-	TakerBuyQuoteAssetVolume float64
-}
+type Kline = kline.Kline
+
+// rawKline decodes a kline from the Binance API.
+type rawKline kline.Kline
 
 // UnmarshalJSON implements a custom unmarshaler for the Kline struct.
 // Binance API returns kline data as a JSON array, not an object.
-func (k *Kline) UnmarshalJSON(data []byte) error {
+func (k *rawKline) UnmarshalJSON(data []byte) error {
 	var raw []interface{}
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return fmt.Errorf("failed to unmarshal kline data: %w", err)
@@ -78,6 +74,12 @@ func (k *Kline) UnmarshalJSON(data []byte) error {
 
 // GetKlines fetches kline/candlestick data for a symbol.
 func (c *Client) GetKlines(ctx context.Context, symbol, interval string, limit int) ([]Kline, error) {
+	return c.GetKlinesBefore(ctx, symbol, interval, limit, 0)
+}
+
+// GetKlinesBefore fetches the klines opening at or before endTime, a Unix
+// timestamp in milliseconds. An endTime of 0 fetches the latest klines.
+func (c *Client) GetKlinesBefore(ctx context.Context, symbol, interval string, limit int, endTime int64) ([]Kline, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+klinesEndpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -87,6 +89,9 @@ func (c *Client) GetKlines(ctx context.Context, symbol, interval string, limit i
 	q.Add("symbol", symbol)
 	q.Add("interval", interval)
 	q.Add("limit", strconv.Itoa(limit))
+	if endTime > 0 {
+		q.Add("endTime", strconv.FormatInt(endTime, 10))
+	}
 	req.URL.RawQuery = q.Encode()
 
 	resp, err := c.HTTPClient.Do(req)
@@ -99,10 +104,55 @@ func (c *Client) GetKlines(ctx context.Context, symbol, interval string, limit i
 		return nil, fmt.Errorf("received non-200 status code: %d", resp.StatusCode)
 	}
 
-	var klines []Kline
-	if err := json.NewDecoder(resp.Body).Decode(&klines); err != nil {
+	var raw []rawKline
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
 		return nil, fmt.Errorf("failed to decode klines response: %w", err)
 	}
 
+	klines := make([]Kline, len(raw))
+	for i, k := range raw {
+		klines[i] = Kline(k)
+	}
+
 	return klines, nil
 }
+
+// symbolInfo is the subset of exchange information needed to list symbols.
+type symbolInfo struct {
+	Symbol     string `json:"symbol"`
+	Status     string `json:"status"`
+	QuoteAsset string `json:"quoteAsset"`
+}
+
+// GetTradingSymbols lists the symbols currently trading against quoteAsset, e.g. "USDT".
+func (c *Client) GetTradingSymbols(ctx context.Context, quoteAsset string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+exchangeInfoEndpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("received non-200 status code: %d", resp.StatusCode)
+	}
+
+	var info struct {
+		Symbols []symbolInfo `json:"symbols"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to decode exchange info response: %w", err)
+	}
+
+	var symbols []string
+	for _, s := range info.Symbols {
+		if s.Status == "TRADING" && s.QuoteAsset == quoteAsset {
+			symbols = append(symbols, s.Symbol)
+		}
+	}
+	return symbols, nil
+}
@@ -7,11 +7,24 @@ import (
 	"time"
 	"tv-bot-go/internal/ai"
 	"tv-bot-go/internal/analysis"
+	"tv-bot-go/internal/binance"
 	"tv-bot-go/internal/market"
+	"tv-bot-go/pkg/charts"
+	"tv-bot-go/pkg/indicators"
+	"unicode/utf8"
 
 	"github.com/bwmarrin/discordgo"
 )
 
+// Summary modes of the /analyze command.
+const (
+	modeAI   = "ai"
+	modeFast = "fast"
+)
+
+// maxTimeframes limits the number of timeframes a single /analyze runs on.
+const maxTimeframes = 4
+
 // Bot represents the Discord bot application.
 type Bot struct {
 	Session         *discordgo.Session
@@ -19,16 +32,24 @@ type Bot struct {
 	AnalysisService *analysis.Service
 	AIService       *ai.Service
 	GuildID         string
+	// Timeframes is the default ordered list of intervals /analyze runs on.
+	Timeframes []string
 }
 
-// NewBot creates a new Bot instance.
-func NewBot(s *discordgo.Session, marketSvc *market.Service, analysisSvc *analysis.Service, aiSvc *ai.Service) *Bot {
+// NewBot creates a new Bot instance. timeframes are the default /analyze
+// timeframes and are checked like the timeframes option.
+func NewBot(s *discordgo.Session, marketSvc *market.Service, analysisSvc *analysis.Service, aiSvc *ai.Service, timeframes []string) (*Bot, error) {
+	timeframes, err := parseTimeframes(strings.Join(timeframes, ","))
+	if err != nil {
+		return nil, fmt.Errorf("invalid default timeframes: %w", err)
+	}
 	return &Bot{
 		Session:         s,
 		MarketService:   marketSvc,
 		AnalysisService: analysisSvc,
 		AIService:       aiSvc,
-	}
+		Timeframes:      timeframes,
+	}, nil
 }
 
 // Start runs the bot and registers command handlers.
@@ -51,31 +72,141 @@ func (b *Bot) Stop() {
 
 func (b *Bot) ready(s *discordgo.Session, event *discordgo.Ready) {
 	fmt.Printf("Logged in as: %v#%v\n", s.State.User.Username, s.State.User.Discriminator)
-	// Register the /analyze command
-	cmd := &discordgo.ApplicationCommand{
-		Name:        "analyze",
-		Description: "Analyze a crypto symbol",
-		Options: []*discordgo.ApplicationCommandOption{
-			{
-				Type:        discordgo.ApplicationCommandOptionString,
-				Name:        "symbol",
-				Description: "Crypto symbol (e.g., BTCUSDT)",
-				Required:    true,
-			},
-		},
+	for _, cmd := range commands() {
+		if _, err := s.ApplicationCommandCreate(s.State.User.ID, b.GuildID, cmd); err != nil {
+			fmt.Printf("Cannot create command %s: %v\n", cmd.Name, err)
+		}
 	}
+}
 
-	_, err := s.ApplicationCommandCreate(s.State.User.ID, b.GuildID, cmd)
-	if err != nil {
-		fmt.Printf("Cannot create command: %v\n", err)
+// commands lists the slash commands registered by the bot.
+func commands() []*discordgo.ApplicationCommand {
+	return []*discordgo.ApplicationCommand{
+		{
+			Name:        "analyze",
+			Description: "Analyze a crypto symbol",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "symbol",
+					Description: "Crypto symbol (e.g., BTCUSDT)",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "timeframes",
+					Description: "Timeframes to analyze, from the primary one, separated by commas (e.g., 4h,1h,15m)",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "anchor",
+					Description: "Anchor a VWAP at a UTC date or time (e.g., 2024-01-15 or 2024-01-15T08:00:00Z)",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "chart",
+					Description: "Chart construction to analyze (default: candles)",
+					Required:    false,
+					Choices:     chartChoices(),
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionNumber,
+					Name:        "size",
+					Description: "Fixed Renko brick or range bar size in price (default: sized by ATR)",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "indicators",
+					Description: "Extra indicators by spec (e.g., ema(200), macd(8,21,5), bb(20,2.5))",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "mode",
+					Description: "Summary mode (default: ai)",
+					Required:    false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "ai", Value: modeAI},
+						{Name: "fast (rule-based, no AI)", Value: modeFast},
+					},
+				},
+			},
+		},
+		{
+			Name:        "correlation",
+			Description: "Show how a crypto symbol correlates with other symbols",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "symbol",
+					Description: "Crypto symbol (e.g., SOLUSDT)",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "symbols",
+					Description: "Symbols to compare with, separated by commas or spaces (default: major pairs)",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "interval",
+					Description: "Kline interval (default: 1h)",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:        "strength",
+			Description: "Rank symbols by relative strength against BTC and ETH",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "universe",
+					Description: "Symbols to rank (default: watchlist)",
+					Required:    false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "watchlist", Value: universeWatchlist},
+						{Name: "all USDT pairs", Value: universeAll},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "symbols",
+					Description: "Watchlist symbols, separated by commas or spaces (default: major pairs)",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "interval",
+					Description: "Kline interval (default: 1h)",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "lookbacks",
+					Description: "Lookbacks in candles, separated by commas (default: 24,168)",
+					Required:    false,
+				},
+			},
+		},
 	}
 }
 
 func (b *Bot) interactionCreate(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	if i.Type == discordgo.InteractionApplicationCommand {
-		if i.ApplicationCommandData().Name == "analyze" {
-			b.handleAnalyzeCommand(s, i)
-		}
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+	switch i.ApplicationCommandData().Name {
+	case "analyze":
+		b.handleAnalyzeCommand(s, i)
+	case "correlation":
+		b.handleCorrelationCommand(s, i)
+	case "strength":
+		b.handleStrengthCommand(s, i)
 	}
 }
 
@@ -85,43 +216,209 @@ func (b *Bot) handleAnalyzeCommand(s *discordgo.Session, i *discordgo.Interactio
 		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
 	})
 
-	options := i.ApplicationCommandData().Options
-	symbol := strings.ToUpper(options[0].Value.(string))
+	options := optionMap(i.ApplicationCommandData().Options)
+	symbol := normalizeSymbol(options["symbol"].StringValue())
 
-	// Default to USDT pair if not specified
-	if !strings.Contains(symbol, "USDT") {
-		symbol += "USDT"
+	timeframes := b.Timeframes
+	if opt, ok := options["timeframes"]; ok {
+		var err error
+		if timeframes, err = parseTimeframes(opt.StringValue()); err != nil {
+			b.sendErrorResponse(s, i.Interaction, err.Error())
+			return
+		}
 	}
 
 	// 1. Fetch Market Data
-	marketData, err := b.MarketService.FetchMarketData(context.Background(), symbol)
+	marketData, err := b.MarketService.FetchMarketData(context.Background(), symbol, timeframes)
 	if err != nil {
 		b.sendErrorResponse(s, i.Interaction, fmt.Sprintf("Error fetching market data for %s: %s", symbol, err))
 		return
 	}
 
-	// 2. Perform Technical Analysis
-	analysis1h := b.AnalysisService.AnalyzeKlines(marketData.Klines1h, "1h")
-	analysis15m := b.AnalysisService.AnalyzeKlines(marketData.Klines15m, "15m")
-
-	// 3. Generate AI Analysis
-	aiSummary, err := b.AIService.GenerateAnalysis(context.Background(), symbol, analysis1h, analysis15m)
-	if err != nil {
-		b.sendErrorResponse(s, i.Interaction, fmt.Sprintf("Error generating AI analysis for %s: %s", symbol, err))
+	// 2. Perform Technical Analysis, optionally on an alternative chart construction
+	chart := charts.Candles
+	if opt, ok := options["chart"]; ok {
+		chart = opt.StringValue()
+	}
+	var size float64
+	chartName := chart
+	if opt, ok := options["size"]; ok {
+		size = opt.FloatValue()
+		chartName = fmt.Sprintf("%s(%g)", chart, size)
+	}
+	var (
+		analyses []*analysis.TechnicalAnalysis
+		klines   [][]binance.Kline
+	)
+	for _, tf := range marketData.Timeframes {
+		chartKlines, err := charts.Apply(chart, tf.Klines, size)
+		if err != nil {
+			b.sendErrorResponse(s, i.Interaction, fmt.Sprintf("Cannot build the %s %s chart: %s", tf.Interval, chart, err))
+			return
+		}
+		if a := b.AnalysisService.AnalyzeKlines(chartKlines, timeframeLabel(tf.Interval, chartName)); a != nil {
+			if len(analyses) == 0 {
+				a.Levels = b.AnalysisService.AnalyzeLevels(tf.Klines, marketData.KlinesDaily, marketData.KlinesWeekly)
+			}
+			analyses = append(analyses, a)
+			klines = append(klines, chartKlines)
+		}
+	}
+	if len(analyses) == 0 {
+		b.sendErrorResponse(s, i.Interaction, fmt.Sprintf("Not enough market data to analyze %s", symbol))
 		return
 	}
 
-	// 4. Send the result
+	// notes are shown in the embed footer
+	var notes []string
+
+	if opt, ok := options["anchor"]; ok {
+		anchor, err := parseAnchor(opt.StringValue())
+		if err != nil {
+			b.sendErrorResponse(s, i.Interaction, err.Error())
+			return
+		}
+		var anchorErrs []string
+		for j, a := range analyses {
+			if err := b.AnalysisService.AnchorVWAP(a, klines[j], anchor); err != nil {
+				anchorErrs = append(anchorErrs, err.Error())
+			}
+		}
+		if len(anchorErrs) == len(analyses) {
+			b.sendErrorResponse(s, i.Interaction, "Cannot anchor the VWAP: "+strings.Join(anchorErrs, "; "))
+			return
+		}
+		for _, e := range anchorErrs {
+			notes = append(notes, "VWAP "+e)
+		}
+	}
+
+	if opt, ok := options["indicators"]; ok {
+		specs, err := indicators.ParseSpecs(opt.StringValue())
+		if err != nil {
+			b.sendErrorResponse(s, i.Interaction, err.Error())
+			return
+		}
+		for j, a := range analyses {
+			if err := b.AnalysisService.AddCustomIndicators(a, klines[j], specs); err != nil {
+				b.sendErrorResponse(s, i.Interaction, err.Error())
+				return
+			}
+		}
+	}
+
+	confluence := b.AnalysisService.AnalyzeConfluence(analyses...)
+
+	// 3. Generate the summary, falling back to the rule-based one if the AI fails
+	var summary string
+	if opt, ok := options["mode"]; ok && opt.StringValue() == modeFast {
+		summary = ai.BuildSummary(symbol, confluence, analyses...)
+		notes = append(notes, "Fast mode: rule-based summary")
+	} else if summary, err = b.AIService.GenerateAnalysis(context.Background(), symbol, analyses, confluence); err != nil {
+		fmt.Printf("Error generating AI analysis for %s: %v\n", symbol, err)
+		summary = ai.BuildSummary(symbol, confluence, analyses...)
+		notes = append(notes, "AI unavailable: rule-based summary")
+	}
+
+	// 4. Send the result, keeping the embed within Discord's size limits
 	embed := &discordgo.MessageEmbed{
 		Title:       fmt.Sprintf("Analysis for %s", symbol),
-		Description: aiSummary,
+		Description: truncate(summary, maxEmbedDescription),
 		Color:       0x0099ff, // Blue
 		Timestamp:   time.Now().UTC().Format(time.RFC3339),
 	}
+	// Leave room for the footer notes, including one about omitted fields.
+	budget := maxEmbedTotal - embedSize(embed) - utf8.RuneCountInString(strings.Join(notes, "\n")) - omittedNoteSize
+	fields, omitted := analysisFields(budget, confluence, analyses...)
+	embed.Fields = fields
+	if omitted > 0 {
+		notes = append(notes, fmt.Sprintf("%d fields omitted to fit Discord's size limits", omitted))
+	}
+	if len(notes) > 0 {
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: strings.Join(notes, "\n")}
+	}
 
-	s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
 		Embeds: &[]*discordgo.MessageEmbed{embed},
-	})
+	}); err != nil {
+		fmt.Printf("Error sending analysis for %s: %v\n", symbol, err)
+		// Fall back to a plain message so that the summary still arrives.
+		content := truncate(fmt.Sprintf("**Analysis for %s**\n%s", symbol, summary), maxMessageContent)
+		if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &content}); err != nil {
+			fmt.Printf("Error sending plain analysis for %s: %v\n", symbol, err)
+		}
+	}
+}
+
+// normalizeSymbol upper-cases a symbol and defaults it to the USDT pair.
+func normalizeSymbol(symbol string) string {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if !strings.Contains(symbol, "USDT") {
+		symbol += "USDT"
+	}
+	return symbol
+}
+
+// optionMap indexes command options by name.
+func optionMap(options []*discordgo.ApplicationCommandInteractionDataOption) map[string]*discordgo.ApplicationCommandInteractionDataOption {
+	m := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		m[opt.Name] = opt
+	}
+	return m
+}
+
+// chartChoices lists the supported chart constructions as command choices.
+func chartChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, len(charts.Types))
+	for i, t := range charts.Types {
+		choices[i] = &discordgo.ApplicationCommandOptionChoice{Name: t, Value: t}
+	}
+	return choices
+}
+
+// timeframeLabel names a timeframe, noting the chart construction if it is not plain candles.
+func timeframeLabel(timeframe, chart string) string {
+	if chart == charts.Candles {
+		return timeframe
+	}
+	return timeframe + " " + chart
+}
+
+// parseTimeframes parses a comma separated, ordered list of kline intervals.
+// Each interval is normalized and checked before duplicates are dropped, so
+// that "1H,1h" is a single timeframe.
+func parseTimeframes(value string) ([]string, error) {
+	var timeframes []string
+	seen := make(map[string]bool)
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+		field, err := market.NormalizeInterval(field)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[field] {
+			seen[field] = true
+			timeframes = append(timeframes, field)
+		}
+	}
+	if len(timeframes) == 0 {
+		return nil, fmt.Errorf("no timeframes given")
+	}
+	if len(timeframes) > maxTimeframes {
+		return nil, fmt.Errorf("too many timeframes: at most %d can be analyzed at once", maxTimeframes)
+	}
+	return timeframes, nil
+}
+
+// parseAnchor parses a VWAP anchor given as a date or an RFC3339 timestamp.
+func parseAnchor(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid anchor %q: use YYYY-MM-DD or an RFC3339 timestamp", value)
 }
 
 func (b *Bot) sendErrorResponse(s *discordgo.Session, i *discordgo.Interaction, errorMsg string) {
@@ -0,0 +1,44 @@
+package bot
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTimeframes(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{in: "1h,15m", want: []string{"1h", "15m"}},
+		{in: "4h 1h, 15m", want: []string{"4h", "1h", "15m"}},
+		{in: "1H,1h", want: []string{"1h"}},
+		{in: "1D,7h,1M", want: []string{"1d", "7h", "1M"}},
+		{in: "1h,1h,1h,1h,1h,4h", want: []string{"1h", "4h"}},
+		{in: "", wantErr: true},
+		{in: " , ", wantErr: true},
+		{in: "1x", wantErr: true},
+		{in: "1h,90s", wantErr: true},
+		{in: "0h", wantErr: true},
+		{in: "1m,5m,15m,1h,4h", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseTimeframes(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewBotRejectsInvalidTimeframes(t *testing.T) {
+	if _, err := NewBot(nil, nil, nil, nil, []string{"1h", "1x"}); err == nil {
+		t.Error("NewBot accepted an invalid default timeframe")
+	}
+}
@@ -0,0 +1,155 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"tv-bot-go/internal/analysis"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// correlationKlines is the number of klines fetched per symbol.
+	correlationKlines = 200
+	// correlationPairs is the number of strongest and weakest pairs listed.
+	correlationPairs = 3
+	// skippedShown is the number of symbols that could not be fetched listed by name.
+	skippedShown = 10
+)
+
+// defaultPeers are compared with the requested symbol when no symbols are given.
+var defaultPeers = []string{"BTCUSDT", "ETHUSDT", "BNBUSDT", "SOLUSDT", "XRPUSDT", "DOGEUSDT", "ADAUSDT", "AVAXUSDT"}
+
+func (b *Bot) handleCorrelationCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	options := optionMap(i.ApplicationCommandData().Options)
+	symbol := normalizeSymbol(options["symbol"].StringValue())
+
+	peers := defaultPeers
+	if opt, ok := options["symbols"]; ok {
+		peers = parseSymbols(opt.StringValue())
+	}
+	interval := "1h"
+	if opt, ok := options["interval"]; ok {
+		interval = opt.StringValue()
+	}
+
+	// Always fetch the benchmark so betas can be reported.
+	symbols := append([]string{symbol, analysis.BetaBenchmark}, peers...)
+	klines, failed, err := b.MarketService.FetchSymbols(context.Background(), symbols, interval, correlationKlines)
+	if err == nil {
+		err = requireFetched(failed, symbol, analysis.BetaBenchmark)
+	}
+	if err != nil {
+		b.sendErrorResponse(s, i.Interaction, fmt.Sprintf("Error fetching market data: %s", err))
+		return
+	}
+
+	report, err := b.AnalysisService.AnalyzeCorrelation(symbol, klines)
+	if err != nil {
+		b.sendErrorResponse(s, i.Interaction, fmt.Sprintf("Error analyzing correlation for %s: %s", symbol, err))
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("Correlation for %s (%s)", symbol, interval),
+		Description: fmt.Sprintf("Pearson correlation of log returns over the candles each pair shares; the rolling value covers the last %d.",
+			report.Window),
+		Color:     0x0099ff, // Blue
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Fields:    correlationFields(report),
+		Footer:    skippedFooter(failed),
+	}
+	if len(report.Dropped) > 0 {
+		text := fmt.Sprintf("Dropped %d symbols sharing too few candles with %s: %s",
+			len(report.Dropped), symbol, strings.Join(report.Dropped, ", "))
+		if embed.Footer != nil {
+			text = embed.Footer.Text + "\n" + text
+		}
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: text}
+	}
+
+	s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{embed},
+	})
+}
+
+// parseSymbols splits a list of symbols separated by commas or spaces.
+func parseSymbols(value string) []string {
+	var symbols []string
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+		symbols = append(symbols, normalizeSymbol(field))
+	}
+	return symbols
+}
+
+// requireFetched returns the fetch error of the first required symbol that failed.
+func requireFetched(failed map[string]error, required ...string) error {
+	for _, symbol := range required {
+		if err, ok := failed[symbol]; ok {
+			return fmt.Errorf("%s: %w", symbol, err)
+		}
+	}
+	return nil
+}
+
+// skippedFooter logs the symbols that could not be fetched and lists them in
+// an embed footer, or returns nil if every symbol was fetched.
+func skippedFooter(failed map[string]error) *discordgo.MessageEmbedFooter {
+	if len(failed) == 0 {
+		return nil
+	}
+	symbols := make([]string, 0, len(failed))
+	for symbol, err := range failed {
+		fmt.Printf("Skipping %s: %v\n", symbol, err)
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	text := fmt.Sprintf("Skipped %d symbols that could not be fetched: ", len(symbols))
+	if len(symbols) > skippedShown {
+		text += strings.Join(symbols[:skippedShown], ", ") + fmt.Sprintf(" and %d more", len(symbols)-skippedShown)
+	} else {
+		text += strings.Join(symbols, ", ")
+	}
+	return &discordgo.MessageEmbedFooter{Text: text}
+}
+
+// correlationFields lists the strongest and weakest correlated pairs and the beta.
+func correlationFields(report *analysis.CorrelationReport) []*discordgo.MessageEmbedField {
+	n := min(correlationPairs, len(report.Pairs))
+	strongest := report.Pairs[:n]
+	var weakest []analysis.PairCorrelation
+	for j := len(report.Pairs) - 1; j >= max(n, len(report.Pairs)-correlationPairs); j-- {
+		weakest = append(weakest, report.Pairs[j])
+	}
+
+	fields := []*discordgo.MessageEmbedField{
+		{Name: "Most Correlated", Value: pairLines(strongest), Inline: true},
+	}
+	if len(weakest) > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: "Least Correlated", Value: pairLines(weakest), Inline: true})
+	}
+	if report.Symbol != analysis.BetaBenchmark {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("Beta vs %s", analysis.BetaBenchmark),
+			Value: fmt.Sprintf("%.2f", report.Beta),
+		})
+	}
+	return fields
+}
+
+// pairLines formats one line per pair with its full-window and rolling correlation.
+func pairLines(pairs []analysis.PairCorrelation) string {
+	lines := make([]string, len(pairs))
+	for j, p := range pairs {
+		lines[j] = fmt.Sprintf("%s: %+.2f (rolling %+.2f, β %.2f, n=%d)", p.Symbol, p.Correlation, p.Rolling, p.Beta, p.Samples)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,303 @@
+package bot
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"tv-bot-go/internal/analysis"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord rejects messages above these sizes, counted in characters.
+const (
+	maxEmbedTotal       = 6000
+	maxEmbedDescription = 4096
+	maxEmbedFields      = 25
+	maxEmbedFieldValue  = 1024
+	maxMessageContent   = 2000
+	// omittedNoteSize is reserved in the footer for the note about omitted fields.
+	omittedNoteSize = 64
+)
+
+// analysisFields builds the embed fields that accompany the AI summary within
+// budget characters. The cross-timeframe fields come first and the rest of the
+// budget is shared evenly by the timeframes, with any share a timeframe leaves
+// unused passed on to the next one. Fields that do not fit are omitted; the
+// number omitted is returned with the fields.
+func analysisFields(budget int, confluence *analysis.Confluence, analyses ...*analysis.TechnicalAnalysis) ([]*discordgo.MessageEmbedField, int) {
+	var fields []*discordgo.MessageEmbedField
+	omitted := 0
+	add := func(field *discordgo.MessageEmbedField, budget *int) {
+		if field == nil {
+			return
+		}
+		field.Value = truncate(field.Value, maxEmbedFieldValue)
+		if size := fieldSize(field); len(fields) < maxEmbedFields && size <= *budget {
+			fields = append(fields, field)
+			*budget -= size
+		} else {
+			omitted++
+		}
+	}
+
+	add(regimeField(analyses...), &budget)
+	add(confluenceField(confluence), &budget)
+
+	var present []*analysis.TechnicalAnalysis
+	for _, a := range analyses {
+		if a != nil {
+			present = append(present, a)
+		}
+	}
+	for j, a := range present {
+		share := budget / (len(present) - j)
+		budget -= share
+		add(movingAveragesField(a), &share)
+		add(vwapField(a), &share)
+		add(eventsField(a), &share)
+		add(volumeField(a), &share)
+		add(statisticsField(a), &share)
+		add(levelsField(a), &share)
+		budget += share
+	}
+	return fields, omitted
+}
+
+// embedSize counts the characters of an embed that Discord limits in total.
+func embedSize(embed *discordgo.MessageEmbed) int {
+	size := utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+	if embed.Footer != nil {
+		size += utf8.RuneCountInString(embed.Footer.Text)
+	}
+	for _, field := range embed.Fields {
+		size += fieldSize(field)
+	}
+	return size
+}
+
+func fieldSize(field *discordgo.MessageEmbedField) int {
+	return utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+}
+
+// truncate shortens s to at most n characters, marking the cut with an ellipsis.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// regimeField summarizes the market regime of every timeframe on one line each.
+func regimeField(analyses ...*analysis.TechnicalAnalysis) *discordgo.MessageEmbedField {
+	var lines []string
+	for _, a := range analyses {
+		if a == nil || a.Regime == nil {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s: **%s** (%.0f%% confidence, ADX %.1f)",
+			a.Timeframe, strings.ReplaceAll(a.Regime.Label, "_", " "), 100*a.Regime.Confidence, a.Regime.ADX))
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:  "Market Regime",
+		Value: strings.Join(lines, "\n"),
+	}
+}
+
+// confluenceField reports the overall confluence bias and the score of each factor.
+func confluenceField(confluence *analysis.Confluence) *discordgo.MessageEmbedField {
+	if confluence == nil {
+		return nil
+	}
+
+	lines := []string{fmt.Sprintf("**%s** (%+.0f)", strings.ToUpper(confluence.Bias[:1])+confluence.Bias[1:], confluence.Score)}
+	for _, f := range confluence.Factors {
+		var timeframes []string
+		for _, tf := range f.Timeframes {
+			timeframes = append(timeframes, fmt.Sprintf("%s %+.0f", tf.Timeframe, tf.Score))
+		}
+		line := fmt.Sprintf("%s%s: %+.0f (%s)", strings.ToUpper(f.Name[:1]), f.Name[1:], f.Score, strings.Join(timeframes, ", "))
+		if !f.Aligned {
+			line += " — mixed"
+		}
+		lines = append(lines, line)
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:  "Confluence",
+		Value: strings.Join(lines, "\n"),
+	}
+}
+
+// movingAveragesField reports price versus the key SMAs and the cross state.
+func movingAveragesField(a *analysis.TechnicalAnalysis) *discordgo.MessageEmbedField {
+	if a.MovingAverages == nil {
+		return nil
+	}
+
+	var lines []string
+	for _, ma := range a.MovingAverages.Averages {
+		side := "below"
+		if ma.Above {
+			side = "above"
+		}
+		lines = append(lines, fmt.Sprintf("SMA %d: %s — %s (%+.2f%%)", ma.Period, formatPrice(ma.Value), side, ma.DistancePercent))
+	}
+	if cross := a.MovingAverages.Cross; cross != nil {
+		line := strings.ToUpper(cross.Status[:1]) + cross.Status[1:] + " cross"
+		if cross.BarsSinceCross >= 0 {
+			line += fmt.Sprintf(" (%d candles ago)", cross.BarsSinceCross)
+		}
+		lines = append(lines, line)
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:   fmt.Sprintf("Moving Averages (%s)", a.Timeframe),
+		Value:  strings.Join(lines, "\n"),
+		Inline: true,
+	}
+}
+
+// vwapField reports the price position relative to each available VWAP.
+func vwapField(a *analysis.TechnicalAnalysis) *discordgo.MessageEmbedField {
+	if a.VWAP == nil {
+		return nil
+	}
+
+	var lines []string
+	for _, v := range []struct {
+		name     string
+		position *analysis.VWAPPosition
+	}{
+		{"Daily", a.VWAP.Daily},
+		{"Weekly", a.VWAP.Weekly},
+		{"Anchored", a.VWAP.Anchored},
+	} {
+		if v.position == nil {
+			continue
+		}
+		if v.position.Partial {
+			v.name += " (partial)"
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s — %s (%+.2f%%)",
+			v.name, formatPrice(v.position.VWAP), strings.ReplaceAll(v.position.Position, "_", " "), v.position.DistancePercent))
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:   fmt.Sprintf("VWAP (%s)", a.Timeframe),
+		Value:  strings.Join(lines, "\n"),
+		Inline: true,
+	}
+}
+
+// maxEmbedEvents is the number of most recent events listed per timeframe.
+const maxEmbedEvents = 5
+
+// eventsField lists the most recent crossover and threshold events, newest first.
+func eventsField(a *analysis.TechnicalAnalysis) *discordgo.MessageEmbedField {
+	if len(a.Events) == 0 {
+		return nil
+	}
+
+	var lines []string
+	for i := len(a.Events) - 1; i >= max(len(a.Events)-maxEmbedEvents, 0); i-- {
+		e := a.Events[i]
+		lines = append(lines, fmt.Sprintf("%s <t:%d:R>", e, e.Time.Unix()))
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:   fmt.Sprintf("Events (%s)", a.Timeframe),
+		Value:  strings.Join(lines, "\n"),
+		Inline: true,
+	}
+}
+
+// volumeField reports relative volume and money flow, flagging abnormal volume.
+func volumeField(a *analysis.TechnicalAnalysis) *discordgo.MessageEmbedField {
+	v := a.Volume
+	if v == nil {
+		return nil
+	}
+
+	status := fmt.Sprintf("RVOL %.2fx (%s) on a %s candle", v.RVOL, v.Status, v.Candle)
+	if v.Abnormal {
+		status = "⚠️ " + status
+	}
+	lines := []string{
+		status,
+		fmt.Sprintf("CMF %+.2f, A/D %s", v.CMF, v.ADTrend),
+		fmt.Sprintf("Volume oscillator %+.1f%%", v.VolumeOscillator),
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:   fmt.Sprintf("Volume (%s)", a.Timeframe),
+		Value:  strings.Join(lines, "\n"),
+		Inline: true,
+	}
+}
+
+// statisticsField reports volatility, z-scores and the Hurst exponent compactly.
+func statisticsField(a *analysis.TechnicalAnalysis) *discordgo.MessageEmbedField {
+	st := a.Statistics
+	if st == nil {
+		return nil
+	}
+
+	lines := []string{
+		fmt.Sprintf("Volatility: %.2f%% per candle, %.0f%% annualized", st.RealizedVolatility, st.AnnualizedVolatility),
+		fmt.Sprintf("Z-score: return %+.2f, price %+.2f", st.ReturnZScore, st.PriceZScore),
+		fmt.Sprintf("Skew %+.2f, kurtosis %+.2f, autocorr %+.2f", st.Skewness, st.Kurtosis, st.Autocorrelation),
+	}
+	if st.HurstRegime != "" {
+		lines = append(lines, fmt.Sprintf("Hurst: %.2f (%s)", st.Hurst, strings.ReplaceAll(st.HurstRegime, "_", " ")))
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:   fmt.Sprintf("Statistics (%s)", a.Timeframe),
+		Value:  strings.Join(lines, "\n"),
+		Inline: true,
+	}
+}
+
+// levelsField lists the nearest levels above and below the current price.
+func levelsField(a *analysis.TechnicalAnalysis) *discordgo.MessageEmbedField {
+	if a.Levels == nil || (len(a.Levels.Above) == 0 && len(a.Levels.Below) == 0) {
+		return nil
+	}
+
+	var lines []string
+	for i := len(a.Levels.Above) - 1; i >= 0; i-- {
+		l := a.Levels.Above[i]
+		lines = append(lines, fmt.Sprintf("▲ %s — %s (%+.2f%%)", formatPrice(l.Price), l.Name, l.DistancePercent))
+	}
+	lines = append(lines, fmt.Sprintf("● %s — price", formatPrice(a.Levels.Price)))
+	for _, l := range a.Levels.Below {
+		lines = append(lines, fmt.Sprintf("▼ %s — %s (%+.2f%%)", formatPrice(l.Price), l.Name, l.DistancePercent))
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:  fmt.Sprintf("Key Levels (%s)", a.Timeframe),
+		Value: strings.Join(lines, "\n"),
+	}
+}
+
+// formatPrice formats a price with a precision suited to its magnitude.
+func formatPrice(price float64) string {
+	switch abs := math.Abs(price); {
+	case abs >= 1000:
+		return fmt.Sprintf("%.2f", price)
+	case abs >= 1:
+		return fmt.Sprintf("%.4f", price)
+	default:
+		return fmt.Sprintf("%.8f", price)
+	}
+}
@@ -0,0 +1,121 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"tv-bot-go/internal/analysis"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"exact", 5, "exact"},
+		{"too long", 5, "too …"},
+		{"ééééé", 3, "éé…"},
+	}
+	for _, tt := range tests {
+		if got := truncate(tt.in, tt.n); got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+		}
+	}
+}
+
+// testAnalysis returns an analysis with four per-timeframe embed fields.
+func testAnalysis(timeframe string) *analysis.TechnicalAnalysis {
+	return &analysis.TechnicalAnalysis{
+		Timeframe: timeframe,
+		Regime:    &analysis.Regime{Label: "ranging", Confidence: 0.5, ADX: 20},
+		MovingAverages: &analysis.MovingAverages{Averages: []analysis.MAPosition{
+			{Period: 20, Value: 100}, {Period: 50, Value: 99}, {Period: 200, Value: 98},
+		}},
+		VWAP:       &analysis.VWAPAnalysis{Daily: &analysis.VWAPPosition{Position: "above"}},
+		Volume:     &analysis.VolumeHealth{RVOL: 1, Status: "normal", Candle: "up", ADTrend: "flat"},
+		Statistics: &analysis.Statistics{HurstRegime: "random_walk"},
+	}
+}
+
+func TestAnalysisFieldsBudget(t *testing.T) {
+	confluence := &analysis.Confluence{Bias: "neutral"}
+	analyses := []*analysis.TechnicalAnalysis{testAnalysis("1h"), testAnalysis("15m"), testAnalysis("4h"), testAnalysis("1d")}
+	all, omitted := analysisFields(maxEmbedTotal, confluence, analyses...)
+	if omitted != 0 || len(all) != 2+4*4 {
+		t.Fatalf("got %d fields and %d omitted with a full budget", len(all), omitted)
+	}
+	perTimeframe := 0
+	for _, f := range all[2:6] {
+		perTimeframe += fieldSize(f)
+	}
+
+	tests := []struct {
+		name   string
+		budget int
+	}{
+		{"shared fields only", fieldSize(all[0]) + fieldSize(all[1])},
+		{"half of each timeframe", fieldSize(all[0]) + fieldSize(all[1]) + 4*perTimeframe/2},
+		{"nothing", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, omitted := analysisFields(tt.budget, confluence, analyses...)
+			size := 0
+			for _, f := range fields {
+				size += fieldSize(f)
+			}
+			if size > tt.budget {
+				t.Errorf("fields take %d characters, above the budget of %d", size, tt.budget)
+			}
+			if len(fields)+omitted != len(all) {
+				t.Errorf("%d fields and %d omitted, want %d in total", len(fields), omitted, len(all))
+			}
+			// Every timeframe gets the same share, so none is left out entirely.
+			if tt.budget > fieldSize(all[0])+fieldSize(all[1]) {
+				for _, a := range analyses {
+					found := false
+					for _, f := range fields {
+						found = found || strings.Contains(f.Name, "("+a.Timeframe+")")
+					}
+					if !found {
+						t.Errorf("no field for %s", a.Timeframe)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestAnalysisFieldsLimits(t *testing.T) {
+	var analyses []*analysis.TechnicalAnalysis
+	for _, tf := range []string{"1m", "5m", "15m", "1h", "4h", "1d", "1w"} {
+		analyses = append(analyses, testAnalysis(tf))
+	}
+	fields, omitted := analysisFields(1<<20, nil, analyses...)
+	if len(fields) != maxEmbedFields || omitted != 1+len(analyses)*4-maxEmbedFields {
+		t.Errorf("got %d fields and %d omitted, want %d fields", len(fields), omitted, maxEmbedFields)
+	}
+
+	a := testAnalysis("1h")
+	a.Regime.Label = strings.Repeat("x", 2*maxEmbedFieldValue)
+	fields, _ = analysisFields(1<<20, nil, a)
+	if n := utf8.RuneCountInString(fields[0].Value); n > maxEmbedFieldValue {
+		t.Errorf("field value has %d characters, above the limit of %d", n, maxEmbedFieldValue)
+	}
+}
+
+func TestEmbedSize(t *testing.T) {
+	embed := &discordgo.MessageEmbed{
+		Title:       "ab",
+		Description: "é",
+		Footer:      &discordgo.MessageEmbedFooter{Text: "cd"},
+		Fields:      []*discordgo.MessageEmbedField{{Name: "e", Value: "fg"}},
+	}
+	if got := embedSize(embed); got != 8 {
+		t.Errorf("embedSize = %d, want 8", got)
+	}
+}
@@ -0,0 +1,143 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"tv-bot-go/internal/analysis"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	universeWatchlist = "watchlist"
+	universeAll       = "all"
+
+	// maxStrengthLookback keeps the required history within a single kline request.
+	maxStrengthLookback = 500
+	// strongestShown and weakestShown are the number of ranked symbols listed.
+	strongestShown = 10
+	weakestShown   = 5
+)
+
+// defaultLookbacks are the relative strength lookbacks, in candles, used when none are given.
+var defaultLookbacks = []int{24, 168}
+
+func (b *Bot) handleStrengthCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	options := optionMap(i.ApplicationCommandData().Options)
+	interval := "1h"
+	if opt, ok := options["interval"]; ok {
+		interval = opt.StringValue()
+	}
+	lookbacks := defaultLookbacks
+	if opt, ok := options["lookbacks"]; ok {
+		var err error
+		if lookbacks, err = parseLookbacks(opt.StringValue()); err != nil {
+			b.sendErrorResponse(s, i.Interaction, err.Error())
+			return
+		}
+	}
+
+	ctx := context.Background()
+	symbols := defaultPeers
+	if opt, ok := options["symbols"]; ok {
+		symbols = parseSymbols(opt.StringValue())
+	}
+	if opt, ok := options["universe"]; ok && opt.StringValue() == universeAll {
+		var err error
+		if symbols, err = b.MarketService.TradingSymbols(ctx, "USDT"); err != nil {
+			b.sendErrorResponse(s, i.Interaction, fmt.Sprintf("Error listing USDT pairs: %s", err))
+			return
+		}
+	}
+
+	longest := 0
+	for _, lookback := range lookbacks {
+		longest = max(longest, lookback)
+	}
+	// One extra candle for the change over the longest lookback and one for the forming candle.
+	klines, failed, err := b.MarketService.FetchSymbols(ctx, append(symbols, analysis.StrengthBenchmarks...), interval, longest+2)
+	if err == nil {
+		err = requireFetched(failed, analysis.StrengthBenchmarks...)
+	}
+	if err != nil {
+		b.sendErrorResponse(s, i.Interaction, fmt.Sprintf("Error fetching market data: %s", err))
+		return
+	}
+
+	scores, err := b.AnalysisService.RankStrength(klines, lookbacks)
+	if err != nil {
+		b.sendErrorResponse(s, i.Interaction, fmt.Sprintf("Error ranking relative strength: %s", err))
+		return
+	}
+
+	lookbackNames := make([]string, len(lookbacks))
+	for j, lookback := range lookbacks {
+		lookbackNames[j] = strconv.Itoa(lookback)
+	}
+	embed := &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("Relative Strength vs BTC & ETH (%s)", interval),
+		Description: fmt.Sprintf("%d symbols ranked by the mean change of their price ratio to BTC and ETH over %s candles. Trend and percentile use the %d-candle lookback.",
+			len(scores), strings.Join(lookbackNames, ", "), longest),
+		Color:     0x0099ff, // Blue
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Fields:    strengthFields(scores, longest),
+		Footer:    skippedFooter(failed),
+	}
+
+	s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{embed},
+	})
+}
+
+// parseLookbacks parses a comma separated list of lookbacks in candles.
+func parseLookbacks(value string) ([]int, error) {
+	var lookbacks []int
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+		lookback, err := strconv.Atoi(field)
+		if err != nil || lookback < 1 || lookback > maxStrengthLookback {
+			return nil, fmt.Errorf("invalid lookback %q: use whole numbers of candles from 1 to %d", field, maxStrengthLookback)
+		}
+		lookbacks = append(lookbacks, lookback)
+	}
+	if len(lookbacks) == 0 {
+		return nil, fmt.Errorf("no lookbacks given")
+	}
+	return lookbacks, nil
+}
+
+// strengthFields lists the strongest and weakest symbols of a ranking.
+func strengthFields(scores []analysis.StrengthScore, trendLookback int) []*discordgo.MessageEmbedField {
+	n := min(strongestShown, len(scores))
+	fields := []*discordgo.MessageEmbedField{
+		{Name: "Strongest", Value: strengthLines(scores[:n], 0, trendLookback)},
+	}
+	if weakest := max(n, len(scores)-weakestShown); weakest < len(scores) {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  "Weakest",
+			Value: strengthLines(scores[weakest:], weakest, trendLookback),
+		})
+	}
+	return fields
+}
+
+// strengthLines formats one ranked line per score, numbered from offset+1.
+func strengthLines(scores []analysis.StrengthScore, offset, trendLookback int) string {
+	lines := make([]string, len(scores))
+	for j, score := range scores {
+		var against []string
+		for _, rs := range score.Against {
+			if rs.Lookback == trendLookback {
+				against = append(against, fmt.Sprintf("%s %s (p%.0f)", strings.TrimSuffix(rs.Benchmark, "USDT"), rs.Trend, rs.Percentile))
+			}
+		}
+		lines[j] = fmt.Sprintf("%d. %s **%+.2f%%** — %s", offset+j+1, score.Symbol, score.Score, strings.Join(against, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
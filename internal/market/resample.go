@@ -0,0 +1,213 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"tv-bot-go/internal/binance"
+)
+
+const (
+	// maxKlineLimit is the largest number of klines Binance returns per request.
+	maxKlineLimit = 1000
+	// maxSourceKlines caps the source klines fetched to resample one interval.
+	maxSourceKlines = 10 * maxKlineLimit
+)
+
+// nativeIntervals lists the Binance kline intervals below one month, from the
+// largest to the smallest, so that the coarsest usable source is found first.
+var nativeIntervals = []string{"1w", "3d", "1d", "12h", "8h", "6h", "4h", "2h", "1h", "30m", "15m", "5m", "3m", "1m"}
+
+// ParseInterval parses an interval such as "10m", "2h", "3D" or "2w" into a duration.
+// Units are minutes (m), hours (h), days (d) and weeks (w); days and weeks may
+// be upper case. Months are not supported because they have no fixed length.
+func ParseInterval(interval string) (time.Duration, error) {
+	if len(interval) < 2 {
+		return 0, fmt.Errorf("invalid interval %q", interval)
+	}
+	n, err := strconv.Atoi(interval[:len(interval)-1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid interval %q", interval)
+	}
+
+	switch unit := interval[len(interval)-1:]; unit {
+	case "m":
+		return time.Duration(n) * time.Minute, nil
+	case "h", "H":
+		return time.Duration(n) * time.Hour, nil
+	case "d", "D":
+		return time.Duration(n) * 24 * time.Hour, nil
+	case "w", "W":
+		return time.Duration(n) * 7 * 24 * time.Hour, nil
+	default:
+		return 0, fmt.Errorf("invalid interval %q: unknown unit %q", interval, unit)
+	}
+}
+
+// isNative reports whether Binance serves the interval directly.
+func isNative(interval string) bool {
+	if interval == "1M" {
+		return true
+	}
+	for _, native := range nativeIntervals {
+		if interval == native {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeInterval returns interval in its canonical form, e.g. "1h" for
+// "1H", and checks that GetKlines can serve it natively or by resampling.
+func NormalizeInterval(interval string) (string, error) {
+	interval = normalizeInterval(interval)
+	if isNative(interval) {
+		return interval, nil
+	}
+	target, err := ParseInterval(interval)
+	if err != nil {
+		return "", err
+	}
+	if _, _, ok := resampleSource(target); !ok {
+		return "", fmt.Errorf("interval %q cannot be built from Binance intervals", interval)
+	}
+	return interval, nil
+}
+
+// resampleSource returns the coarsest native interval that divides target evenly.
+func resampleSource(target time.Duration) (native string, source time.Duration, ok bool) {
+	for _, native := range nativeIntervals {
+		source, _ := ParseInterval(native)
+		if source < target && target%source == 0 {
+			return native, source, true
+		}
+	}
+	return "", 0, false
+}
+
+// GetKlines fetches klines for any interval. Native Binance intervals are
+// fetched directly; other intervals are resampled from the coarsest native
+// interval that divides them evenly.
+func (s *Service) GetKlines(ctx context.Context, symbol, interval string, limit int) ([]binance.Kline, error) {
+	interval = normalizeInterval(interval)
+	if isNative(interval) {
+		return s.binanceClient.GetKlines(ctx, symbol, interval, limit)
+	}
+
+	target, err := ParseInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+	native, source, ok := resampleSource(target)
+	if !ok {
+		return nil, fmt.Errorf("interval %q cannot be built from Binance intervals", interval)
+	}
+
+	factor := int(target / source)
+	// Fetch one extra bucket so that a partial first bucket can be dropped.
+	sourceLimit := (limit + 1) * factor
+	if sourceLimit > maxSourceKlines {
+		return nil, fmt.Errorf("%d %s klines need %d %s klines, more than the limit of %d", limit, interval, sourceLimit, native, maxSourceKlines)
+	}
+	klines, err := s.fetchKlines(ctx, symbol, native, sourceLimit)
+	if err != nil {
+		return nil, err
+	}
+	resampled, err := Resample(klines, source, target)
+	if err != nil {
+		return nil, err
+	}
+	if len(resampled) > limit {
+		resampled = resampled[len(resampled)-limit:]
+	}
+	return resampled, nil
+}
+
+// fetchKlines fetches the latest limit klines, paging back through history
+// when the limit exceeds a single request. It returns fewer klines only if the
+// symbol has no older history.
+func (s *Service) fetchKlines(ctx context.Context, symbol, interval string, limit int) ([]binance.Kline, error) {
+	var klines []binance.Kline
+	var endTime int64
+	for len(klines) < limit {
+		size := min(limit-len(klines), maxKlineLimit)
+		page, err := s.binanceClient.GetKlinesBefore(ctx, symbol, interval, size, endTime)
+		if err != nil {
+			return nil, err
+		}
+		klines = append(page, klines...)
+		if len(page) < size {
+			break
+		}
+		endTime = page[0].OpenTime - 1
+	}
+	return klines, nil
+}
+
+// Resample aggregates klines of the source interval into klines of the target
+// interval, which must be a multiple of the source. Buckets are aligned to the
+// Unix epoch, or to Monday 00:00 UTC for multiples of a week. Volumes, quote
+// volumes, trade counts and taker volumes are summed. A leading bucket that is
+// missing source klines is dropped; the last bucket is kept even if it is still
+// forming, with the close time of the full bucket.
+func Resample(klines []binance.Kline, source, target time.Duration) ([]binance.Kline, error) {
+	if source <= 0 || target%source != 0 {
+		return nil, fmt.Errorf("cannot resample %s klines into %s", source, target)
+	}
+	if len(klines) == 0 {
+		return nil, nil
+	}
+
+	targetMillis := target.Milliseconds()
+	var offset int64
+	if week := (7 * 24 * time.Hour).Milliseconds(); targetMillis%week == 0 {
+		// The Unix epoch is a Thursday; Binance weeks start on Monday.
+		offset = (4 * 24 * time.Hour).Milliseconds()
+	}
+	bucketStart := func(openTime int64) int64 {
+		return openTime - ((openTime-offset)%targetMillis+targetMillis)%targetMillis
+	}
+
+	expected := int(target / source)
+	var result []binance.Kline
+	var counts []int
+	for _, k := range klines {
+		start := bucketStart(k.OpenTime)
+		if n := len(result); n > 0 && result[n-1].OpenTime == start {
+			bar := &result[n-1]
+			bar.High = max(bar.High, k.High)
+			bar.Low = min(bar.Low, k.Low)
+			bar.Close = k.Close
+			bar.Volume += k.Volume
+			bar.QuoteAssetVolume += k.QuoteAssetVolume
+			bar.NumberOfTrades += k.NumberOfTrades
+			bar.TakerBuyBaseAssetVolume += k.TakerBuyBaseAssetVolume
+			bar.TakerBuyQuoteAssetVolume += k.TakerBuyQuoteAssetVolume
+			counts[n-1]++
+			continue
+		}
+
+		bar := k
+		bar.OpenTime = start
+		bar.CloseTime = start + targetMillis - 1
+		result = append(result, bar)
+		counts = append(counts, 1)
+	}
+
+	if len(result) > 1 && counts[0] < expected {
+		result = result[1:]
+	}
+	return result, nil
+}
+
+// normalizeInterval lower-cases hour, day and week units while keeping "M"
+// (months) distinct from "m" (minutes).
+func normalizeInterval(interval string) string {
+	interval = strings.TrimSpace(interval)
+	if strings.HasSuffix(interval, "M") {
+		return interval
+	}
+	return strings.ToLower(interval)
+}
@@ -0,0 +1,239 @@
+package market
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+	"tv-bot-go/internal/binance"
+)
+
+const hourMillis = int64(3600000)
+
+// hourlyKlines returns n hourly klines opening at start, each with a volume of 1.
+func hourlyKlines(start int64, n int) []binance.Kline {
+	klines := make([]binance.Kline, n)
+	for i := range klines {
+		open := start + int64(i)*hourMillis
+		p := float64(i)
+		klines[i] = binance.Kline{OpenTime: open, CloseTime: open + hourMillis - 1, Open: p, High: p + 1, Low: p - 1, Close: p + 0.5, Volume: 1, NumberOfTrades: 2}
+	}
+	return klines
+}
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "10m", want: 10 * time.Minute},
+		{in: "2h", want: 2 * time.Hour},
+		{in: "2H", want: 2 * time.Hour},
+		{in: "3D", want: 72 * time.Hour},
+		{in: "2w", want: 14 * 24 * time.Hour},
+		{in: "1M", wantErr: true},
+		{in: "90s", wantErr: true},
+		{in: "0h", wantErr: true},
+		{in: "-1h", wantErr: true},
+		{in: "h", wantErr: true},
+		{in: "1x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseInterval(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeInterval(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "1h", want: "1h"},
+		{in: " 1H ", want: "1h"},
+		{in: "3D", want: "3d"},
+		{in: "1W", want: "1w"},
+		{in: "1M", want: "1M"},
+		{in: "7h", want: "7h"},
+		{in: "10m", want: "10m"},
+		{in: "2w", want: "2w"},
+		{in: "1x", wantErr: true},
+		{in: "90s", wantErr: true},
+		{in: "2M", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := NormalizeInterval(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResample(t *testing.T) {
+	// 2024-01-01 is a Monday; start at 02:00 so the first 4h bucket is partial.
+	monday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+
+	tests := []struct {
+		name      string
+		klines    []binance.Kline
+		target    time.Duration
+		wantOpens []int64 // open times relative to monday, in hours
+		wantVol   []float64
+	}{
+		{
+			name:      "aligned",
+			klines:    hourlyKlines(monday, 8),
+			target:    4 * time.Hour,
+			wantOpens: []int64{0, 4},
+			wantVol:   []float64{4, 4},
+		},
+		{
+			name:      "partial first bucket dropped",
+			klines:    hourlyKlines(monday+2*hourMillis, 8),
+			target:    4 * time.Hour,
+			wantOpens: []int64{4, 8},
+			wantVol:   []float64{4, 2},
+		},
+		{
+			name:      "forming last bucket kept",
+			klines:    hourlyKlines(monday, 5),
+			target:    4 * time.Hour,
+			wantOpens: []int64{0, 4},
+			wantVol:   []float64{4, 1},
+		},
+		{
+			name:      "single partial bucket kept",
+			klines:    hourlyKlines(monday+hourMillis, 2),
+			target:    4 * time.Hour,
+			wantOpens: []int64{0},
+			wantVol:   []float64{2},
+		},
+		{
+			name:      "weeks start on monday",
+			klines:    hourlyKlines(monday-24*hourMillis, 24+7*24),
+			target:    7 * 24 * time.Hour,
+			wantOpens: []int64{0},
+			wantVol:   []float64{7 * 24},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resample(tt.klines, time.Hour, tt.target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.wantOpens) {
+				t.Fatalf("got %d klines, want %d", len(got), len(tt.wantOpens))
+			}
+			for i, k := range got {
+				if want := monday + tt.wantOpens[i]*hourMillis; k.OpenTime != want {
+					t.Errorf("kline %d opens at %s, want %s", i, time.UnixMilli(k.OpenTime).UTC(), time.UnixMilli(want).UTC())
+				}
+				if want := k.OpenTime + tt.target.Milliseconds() - 1; k.CloseTime != want {
+					t.Errorf("kline %d closes at %d, want %d", i, k.CloseTime, want)
+				}
+				if k.Volume != tt.wantVol[i] {
+					t.Errorf("kline %d volume = %g, want %g", i, k.Volume, tt.wantVol[i])
+				}
+			}
+		})
+	}
+
+	if _, err := Resample(hourlyKlines(monday, 4), time.Hour, 90*time.Minute); err == nil {
+		t.Error("resampling into a non-multiple succeeded")
+	}
+}
+
+// fakeBinance serves history as hourly klines ending at the last one, honoring
+// the limit and endTime parameters, and counts the requests it receives.
+func fakeBinance(t *testing.T, history []binance.Kline) (*Service, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		q := r.URL.Query()
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		if limit > maxKlineLimit {
+			t.Errorf("requested %d klines, above the Binance limit", limit)
+		}
+		end := len(history)
+		if endTime, err := strconv.ParseInt(q.Get("endTime"), 10, 64); err == nil {
+			for end > 0 && history[end-1].OpenTime > endTime {
+				end--
+			}
+		}
+		var rows [][]interface{}
+		for _, k := range history[max(end-limit, 0):end] {
+			f := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+			rows = append(rows, []interface{}{k.OpenTime, f(k.Open), f(k.High), f(k.Low), f(k.Close), f(k.Volume),
+				k.CloseTime, f(k.QuoteAssetVolume), k.NumberOfTrades, f(k.TakerBuyBaseAssetVolume), f(k.TakerBuyQuoteAssetVolume), "0"})
+		}
+		json.NewEncoder(w).Encode(rows)
+	}))
+	t.Cleanup(server.Close)
+
+	client := binance.NewClient()
+	client.BaseURL = server.URL
+	return NewService(client), &requests
+}
+
+func TestGetKlinesPagesSourceKlines(t *testing.T) {
+	tests := []struct {
+		name         string
+		history      int
+		interval     string
+		limit        int
+		want         int
+		wantRequests int
+		wantErr      bool
+	}{
+		{name: "native", history: 5000, interval: "1h", limit: 300, want: 300, wantRequests: 1},
+		{name: "one page", history: 5000, interval: "5h", limit: 100, want: 100, wantRequests: 1},
+		// 301 buckets of 7h need 2107 hourly klines.
+		{name: "several pages", history: 5000, interval: "7h", limit: 300, want: 300, wantRequests: 3},
+		{name: "short history", history: 700, interval: "7h", limit: 300, want: 100, wantRequests: 1},
+		{name: "too many source klines", history: 5000, interval: "127m", limit: 300, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Start at an hour that is a multiple of 7 so that every bucket is complete.
+			s, requests := fakeBinance(t, hourlyKlines(7*hourMillis*1000, tt.history))
+			got, err := s.GetKlines(context.Background(), "BTCUSDT", tt.interval, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Fatalf("got %d klines, want %d", len(got), tt.want)
+			}
+			if *requests != tt.wantRequests {
+				t.Errorf("made %d requests, want %d", *requests, tt.wantRequests)
+			}
+			for i := 1; i < len(got); i++ {
+				if got[i].OpenTime <= got[i-1].OpenTime {
+					t.Fatalf("kline %d opens at %d, not after %d", i, got[i].OpenTime, got[i-1].OpenTime)
+				}
+			}
+		})
+	}
+}
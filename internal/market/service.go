@@ -2,9 +2,15 @@ package market
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"tv-bot-go/internal/binance"
 )
 
+// klineLimit is the number of candles fetched per timeframe. It covers the
+// warm-up of the 200 period moving average.
+const klineLimit = 300
+
 // Service provides market data and analysis.
 type Service struct {
 	binanceClient *binance.Client
@@ -15,28 +21,100 @@ func NewService(binanceClient *binance.Client) *Service {
 	return &Service{binanceClient: binanceClient}
 }
 
-// MarketData holds the raw kline data for different timeframes.
+// MarketData holds the raw kline data for an ordered list of timeframes.
 type MarketData struct {
-	Klines1h  []binance.Kline
-	Klines15m []binance.Kline
+	Timeframes []TimeframeKlines
+	// KlinesDaily and KlinesWeekly hold the last few daily and weekly candles,
+	// used for pivot points.
+	KlinesDaily  []binance.Kline
+	KlinesWeekly []binance.Kline
+}
+
+// TimeframeKlines holds the klines of one timeframe.
+type TimeframeKlines struct {
+	Interval string
+	Klines   []binance.Kline
 }
 
-// FetchMarketData fetches kline data for a given symbol for 1h and 15m intervals.
-func (s *Service) FetchMarketData(ctx context.Context, symbol string) (*MarketData, error) {
-	// Fetch 1-hour klines
-	klines1h, err := s.binanceClient.GetKlines(ctx, symbol, "1h", 100)
+// FetchMarketData fetches kline data for a given symbol for each interval, in
+// order, plus the most recent daily and weekly candles. Intervals Binance does
+// not serve are resampled.
+func (s *Service) FetchMarketData(ctx context.Context, symbol string, intervals []string) (*MarketData, error) {
+	data := &MarketData{}
+	for _, interval := range intervals {
+		klines, err := s.GetKlines(ctx, symbol, interval, klineLimit)
+		if err != nil {
+			return nil, err
+		}
+		data.Timeframes = append(data.Timeframes, TimeframeKlines{Interval: interval, Klines: klines})
+	}
+
+	// Fetch the latest daily and weekly candles
+	var err error
+	data.KlinesDaily, err = s.GetKlines(ctx, symbol, "1d", 3)
 	if err != nil {
 		return nil, err
 	}
 
-	// Fetch 15-minute klines
-	klines15m, err := s.binanceClient.GetKlines(ctx, symbol, "15m", 100)
+	data.KlinesWeekly, err = s.GetKlines(ctx, symbol, "1w", 3)
 	if err != nil {
 		return nil, err
 	}
 
-	return &MarketData{
-		Klines1h:  klines1h,
-		Klines15m: klines15m,
-	}, nil
+	return data, nil
+}
+
+// maxConcurrentFetches limits the number of kline requests FetchSymbols runs at once.
+const maxConcurrentFetches = 8
+
+// FetchSymbols fetches klines of the same interval for several symbols, keyed by symbol.
+// Requests run concurrently. Symbols that cannot be fetched, e.g. because they
+// were delisted or rate limited, are skipped and returned in failed with their
+// error; an error is only returned if no symbol could be fetched.
+func (s *Service) FetchSymbols(ctx context.Context, symbols []string, interval string, limit int) (klines map[string][]binance.Kline, failed map[string]error, err error) {
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		slots = make(chan struct{}, maxConcurrentFetches)
+	)
+	klines = make(map[string][]binance.Kline, len(symbols))
+	failed = make(map[string]error)
+	seen := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		if seen[symbol] {
+			continue
+		}
+		seen[symbol] = true
+
+		wg.Add(1)
+		go func(symbol string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			result, err := s.GetKlines(ctx, symbol, interval, limit)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed[symbol] = err
+				return
+			}
+			klines[symbol] = result
+		}(symbol)
+	}
+	wg.Wait()
+
+	if len(klines) == 0 {
+		for _, symbol := range symbols {
+			if err, ok := failed[symbol]; ok {
+				return nil, failed, fmt.Errorf("%s: %w", symbol, err)
+			}
+		}
+	}
+	return klines, failed, nil
+}
+
+// TradingSymbols lists the symbols currently trading against quoteAsset, e.g. "USDT".
+func (s *Service) TradingSymbols(ctx context.Context, quoteAsset string) ([]string, error) {
+	return s.binanceClient.GetTradingSymbols(ctx, quoteAsset)
 }
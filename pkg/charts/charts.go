@@ -0,0 +1,66 @@
+package charts
+
+import (
+	"fmt"
+	"math"
+	"tv-bot-go/pkg/kline"
+)
+
+// Chart type names accepted by Apply.
+const (
+	Candles         = "candles"
+	HeikinAshiChart = "heikin-ashi"
+	RenkoChart      = "renko"
+	RangeChart      = "range"
+)
+
+// Types lists every chart type accepted by Apply.
+var Types = []string{Candles, HeikinAshiChart, RenkoChart, RangeChart}
+
+const (
+	// atrPeriod is the ATR period used to size Renko bricks and range bars.
+	atrPeriod = 14
+	// minSizeATR and minSizePrice give the smallest fixed size Apply accepts,
+	// as fractions of the latest ATR and close; the larger of the two applies.
+	minSizeATR   = 0.1
+	minSizePrice = 0.0001
+	// MaxBars caps the number of bars Renko and RangeBars generate.
+	MaxBars = 10000
+)
+
+// Apply converts klines into the named chart type. Renko bricks and range bars
+// are size wide in price if size is positive, and are otherwise sized by the
+// ATR of the source klines. Other chart types do not take a size.
+func Apply(chart string, klines []kline.Kline, size float64) ([]kline.Kline, error) {
+	if size < 0 || math.IsNaN(size) || math.IsInf(size, 0) {
+		return nil, fmt.Errorf("invalid size %g: must be a positive number", size)
+	}
+	if size > 0 && chart != RenkoChart && chart != RangeChart {
+		return nil, fmt.Errorf("a size only applies to %s and %s charts", RenkoChart, RangeChart)
+	}
+	if size > 0 && len(klines) > 0 {
+		minSize := max(minSizeATR*latestATR(klines, atrPeriod), minSizePrice*klines[len(klines)-1].Close)
+		if size < minSize {
+			return nil, fmt.Errorf("size %g is too small: must be at least %.6g", size, minSize)
+		}
+	}
+
+	switch chart {
+	case "", Candles:
+		return klines, nil
+	case HeikinAshiChart:
+		return HeikinAshi(klines), nil
+	case RenkoChart:
+		if size > 0 {
+			return Renko(klines, size)
+		}
+		return RenkoATR(klines, atrPeriod)
+	case RangeChart:
+		if size > 0 {
+			return RangeBars(klines, size)
+		}
+		return RangeBarsATR(klines, atrPeriod)
+	default:
+		return nil, fmt.Errorf("unknown chart type %q", chart)
+	}
+}
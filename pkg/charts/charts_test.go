@@ -0,0 +1,120 @@
+package charts
+
+import (
+	"math"
+	"testing"
+	"tv-bot-go/pkg/kline"
+)
+
+// trendKlines returns n hourly klines rising by step per kline.
+func trendKlines(n int, step float64) []kline.Kline {
+	klines := make([]kline.Kline, n)
+	for i := range klines {
+		open := 100 + step*float64(i)
+		klines[i] = kline.Kline{
+			OpenTime:  int64(i) * 3600000,
+			CloseTime: int64(i+1)*3600000 - 1,
+			Open:      open,
+			High:      open + step,
+			Low:       open,
+			Close:     open + step,
+			Volume:    10,
+		}
+	}
+	return klines
+}
+
+func TestApplySize(t *testing.T) {
+	klines := trendKlines(50, 1) // ATR is 1
+
+	tests := []struct {
+		name    string
+		chart   string
+		size    float64
+		wantErr bool
+	}{
+		{name: "candles", chart: Candles},
+		{name: "renko by ATR", chart: RenkoChart},
+		{name: "range by ATR", chart: RangeChart},
+		{name: "renko fixed", chart: RenkoChart, size: 2},
+		{name: "range at minimum", chart: RangeChart, size: minSizeATR},
+		{name: "renko below minimum", chart: RenkoChart, size: minSizeATR / 2, wantErr: true},
+		{name: "range below minimum", chart: RangeChart, size: 1e-9, wantErr: true},
+		{name: "negative", chart: RenkoChart, size: -1, wantErr: true},
+		{name: "nan", chart: RenkoChart, size: math.NaN(), wantErr: true},
+		{name: "inf", chart: RangeChart, size: math.Inf(1), wantErr: true},
+		{name: "size on candles", chart: Candles, size: 1, wantErr: true},
+		{name: "unknown chart", chart: "kagi", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bars, err := Apply(tt.chart, klines, tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(bars) == 0 {
+				t.Error("no bars")
+			}
+		})
+	}
+}
+
+func TestBarLimit(t *testing.T) {
+	klines := trendKlines(10, 1)
+
+	tests := []struct {
+		name    string
+		build   func(size float64) ([]kline.Kline, error)
+		size    float64
+		want    int
+		wantErr bool
+	}{
+		// Each kline rises 1 and forms 1/size bricks; the first kline sets the base.
+		{name: "renko", build: func(size float64) ([]kline.Kline, error) { return Renko(klines, size) }, size: 0.5, want: 18},
+		{name: "renko over limit", build: func(size float64) ([]kline.Kline, error) { return Renko(klines, size) }, size: 1e-5, wantErr: true},
+		// The range bars span the whole move of 10, plus the open last bar.
+		{name: "range", build: func(size float64) ([]kline.Kline, error) { return RangeBars(klines, size) }, size: 0.5, want: 21},
+		{name: "range over limit", build: func(size float64) ([]kline.Kline, error) { return RangeBars(klines, size) }, size: 1e-5, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bars, err := tt.build(tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(bars) != tt.want {
+				t.Errorf("got %d bars, want %d", len(bars), tt.want)
+			}
+			if len(bars) > MaxBars {
+				t.Errorf("got %d bars, above the limit of %d", len(bars), MaxBars)
+			}
+		})
+	}
+}
+
+func TestRenkoSplitsTimeAndVolume(t *testing.T) {
+	klines := trendKlines(3, 2)
+	bricks, err := Renko(klines, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bricks) != 4 {
+		t.Fatalf("got %d bricks, want 4", len(bricks))
+	}
+
+	var volume float64
+	for i, b := range bricks {
+		volume += b.Volume
+		if b.CloseTime < b.OpenTime {
+			t.Errorf("brick %d closes at %d before it opens at %d", i, b.CloseTime, b.OpenTime)
+		}
+		if i > 0 && b.OpenTime <= bricks[i-1].OpenTime {
+			t.Errorf("brick %d opens at %d, not after brick %d", i, b.OpenTime, i-1)
+		}
+	}
+	if volume != 30 {
+		t.Errorf("total volume = %g, want 30", volume)
+	}
+}
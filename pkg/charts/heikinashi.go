@@ -0,0 +1,28 @@
+// Package charts converts klines into alternative chart constructions. Every
+// transform returns kline.Kline values so that all indicators can run on them.
+package charts
+
+import "tv-bot-go/pkg/kline"
+
+// HeikinAshi converts klines into Heikin-Ashi candles. Times, volumes and trade
+// counts are carried over unchanged.
+func HeikinAshi(klines []kline.Kline) []kline.Kline {
+	if len(klines) == 0 {
+		return nil
+	}
+
+	result := make([]kline.Kline, len(klines))
+	for i, k := range klines {
+		ha := k
+		ha.Close = (k.Open + k.High + k.Low + k.Close) / 4
+		if i == 0 {
+			ha.Open = (k.Open + k.Close) / 2
+		} else {
+			ha.Open = (result[i-1].Open + result[i-1].Close) / 2
+		}
+		ha.High = max(k.High, ha.Open, ha.Close)
+		ha.Low = min(k.Low, ha.Open, ha.Close)
+		result[i] = ha
+	}
+	return result
+}
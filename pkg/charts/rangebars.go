@@ -0,0 +1,88 @@
+package charts
+
+import (
+	"fmt"
+	"tv-bot-go/pkg/kline"
+)
+
+// RangeBars converts klines into range bars that each span rangeSize from high
+// to low. Intra-kline price movement is approximated as open, then the nearer
+// extreme (low for bullish klines, high for bearish ones), then the other
+// extreme, then close. The time span and volume of a kline are split evenly
+// across the bars it touches. The last bar is returned even if it is incomplete.
+// It returns an error if the klines would form more than MaxBars bars.
+func RangeBars(klines []kline.Kline, rangeSize float64) ([]kline.Kline, error) {
+	if len(klines) == 0 || rangeSize <= 0 {
+		return nil, nil
+	}
+
+	var bars []kline.Kline
+	bar := newBar(klines[0].OpenTime, klines[0].Open)
+	for _, k := range klines {
+		start := len(bars)
+		path := []float64{k.Open, k.High, k.Low, k.Close}
+		if k.Close >= k.Open {
+			path = []float64{k.Open, k.Low, k.High, k.Close}
+		}
+		for _, target := range path {
+			for {
+				if target > bar.High {
+					if target-bar.Low < rangeSize {
+						bar.High = target
+						break
+					}
+					bar.High = bar.Low + rangeSize
+					bar.Close = bar.High
+				} else if target < bar.Low {
+					if bar.High-target < rangeSize {
+						bar.Low = target
+						break
+					}
+					bar.Low = bar.High - rangeSize
+					bar.Close = bar.Low
+				} else {
+					break
+				}
+
+				// The bar reached its full range; start a new one at its close.
+				if len(bars) == MaxBars-1 {
+					return nil, fmt.Errorf("range size %g forms more than %d bars", rangeSize, MaxBars)
+				}
+				bars = append(bars, bar)
+				bar = newBar(k.OpenTime, bar.Close)
+			}
+			bar.Close = target
+		}
+
+		// The bars completed during k and the bar still open share its time and volume.
+		parts := splitKline(k, len(bars)-start+1)
+		for j, part := range parts {
+			touched := &bar
+			if j < len(parts)-1 {
+				touched = &bars[start+j]
+			}
+			if j > 0 {
+				touched.OpenTime = part.OpenTime
+			}
+			touched.CloseTime = part.CloseTime
+			accumulate(touched, part)
+		}
+	}
+	return append(bars, bar), nil
+}
+
+// newBar starts an empty bar at price, opening at openTime.
+func newBar(openTime int64, price float64) kline.Kline {
+	return kline.Kline{
+		OpenTime: openTime,
+		Open:     price,
+		High:     price,
+		Low:      price,
+		Close:    price,
+	}
+}
+
+// RangeBarsATR converts klines into range bars sized by the latest ATR over period.
+func RangeBarsATR(klines []kline.Kline, period int) ([]kline.Kline, error) {
+	return RangeBars(klines, latestATR(klines, period))
+}
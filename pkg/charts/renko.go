@@ -0,0 +1,102 @@
+package charts
+
+import (
+	"fmt"
+	"tv-bot-go/pkg/indicators"
+	"tv-bot-go/pkg/kline"
+)
+
+// Renko converts klines into Renko bricks of a fixed size based on closing
+// prices. A new brick forms when the close moves one brick beyond the last
+// brick in the current direction, or two bricks against it. The time span and
+// volume of a kline are split evenly across the bricks it forms, and the
+// volume of klines that form no brick is carried into the next brick.
+// It returns an error if the klines would form more than MaxBars bricks.
+func Renko(klines []kline.Kline, brickSize float64) ([]kline.Kline, error) {
+	if len(klines) == 0 || brickSize <= 0 {
+		return nil, nil
+	}
+
+	var bricks []kline.Kline
+	var pending kline.Kline
+	top, bottom := klines[0].Close, klines[0].Close
+	for _, k := range klines {
+		start := len(bricks)
+		for {
+			var open, close float64
+			if k.Close >= top+brickSize {
+				open, close = top, top+brickSize
+			} else if k.Close <= bottom-brickSize {
+				open, close = bottom, bottom-brickSize
+			} else {
+				break
+			}
+			if len(bricks) == MaxBars {
+				return nil, fmt.Errorf("brick size %g forms more than %d bricks", brickSize, MaxBars)
+			}
+
+			brick := kline.Kline{Open: open, Close: close, High: max(open, close), Low: min(open, close)}
+			bricks = append(bricks, brick)
+			top, bottom = brick.High, brick.Low
+		}
+
+		formed := bricks[start:]
+		if len(formed) == 0 {
+			accumulate(&pending, k)
+			continue
+		}
+		accumulate(&formed[0], pending)
+		pending = kline.Kline{}
+		for j, part := range splitKline(k, len(formed)) {
+			formed[j].OpenTime, formed[j].CloseTime = part.OpenTime, part.CloseTime
+			accumulate(&formed[j], part)
+		}
+	}
+	return bricks, nil
+}
+
+// RenkoATR converts klines into Renko bricks sized by the latest ATR over period.
+func RenkoATR(klines []kline.Kline, period int) ([]kline.Kline, error) {
+	return Renko(klines, latestATR(klines, period))
+}
+
+// accumulate adds a kline's volume fields to a pending bar.
+func accumulate(bar *kline.Kline, k kline.Kline) {
+	bar.Volume += k.Volume
+	bar.QuoteAssetVolume += k.QuoteAssetVolume
+	bar.NumberOfTrades += k.NumberOfTrades
+	bar.TakerBuyBaseAssetVolume += k.TakerBuyBaseAssetVolume
+	bar.TakerBuyQuoteAssetVolume += k.TakerBuyQuoteAssetVolume
+}
+
+// splitKline divides the time span and volume fields of k evenly into n parts,
+// so that bars formed within one kline keep distinct times and share its volume.
+func splitKline(k kline.Kline, n int) []kline.Kline {
+	parts := make([]kline.Kline, n)
+	span := k.CloseTime - k.OpenTime + 1
+	for j := range parts {
+		parts[j] = kline.Kline{
+			OpenTime:                 k.OpenTime + span*int64(j)/int64(n),
+			CloseTime:                k.OpenTime + span*int64(j+1)/int64(n) - 1,
+			Volume:                   k.Volume / float64(n),
+			QuoteAssetVolume:         k.QuoteAssetVolume / float64(n),
+			NumberOfTrades:           k.NumberOfTrades / int64(n),
+			TakerBuyBaseAssetVolume:  k.TakerBuyBaseAssetVolume / float64(n),
+			TakerBuyQuoteAssetVolume: k.TakerBuyQuoteAssetVolume / float64(n),
+		}
+		if int64(j) < k.NumberOfTrades%int64(n) {
+			parts[j].NumberOfTrades++
+		}
+	}
+	return parts
+}
+
+// latestATR returns the most recent ATR value, or 0 if there is not enough data.
+func latestATR(klines []kline.Kline, period int) float64 {
+	highs, lows, closes, _ := kline.HLCV(klines)
+	atr := indicators.CalculateATR(highs, lows, closes, period)
+	if len(atr) == 0 {
+		return 0
+	}
+	return atr[len(atr)-1]
+}
@@ -11,6 +11,12 @@ type Config struct {
 	GuildID      string `mapstructure:"DISCORD_GUILD_ID"`
 	AIAPIKey     string `mapstructure:"DEEPSEEK_API_KEY"`
 	AIEndpoint   string `mapstructure:"AI_ENDPOINT"`
+	// HistoryWindow is the number of recent indicator values passed to the AI,
+	// at most analysis.MaxHistoryWindow.
+	HistoryWindow int `mapstructure:"HISTORY_WINDOW"`
+	// Timeframes is the default ordered list of intervals /analyze runs on,
+	// from the primary timeframe to the last, e.g. "1h,15m".
+	Timeframes []string `mapstructure:"TIMEFRAMES"`
 }
 
 // LoadConfig reads configuration from file or environment variables.
@@ -20,6 +26,10 @@ func LoadConfig(path string) (config Config, err error) {
 	viper.BindEnv("DEEPSEEK_API_KEY")
 	viper.SetDefault("AI_ENDPOINT", "https://api.deepseek.com/chat/completions")
 	viper.BindEnv("AI_ENDPOINT")
+	viper.SetDefault("HISTORY_WINDOW", 10)
+	viper.BindEnv("HISTORY_WINDOW")
+	viper.SetDefault("TIMEFRAMES", "1h,15m")
+	viper.BindEnv("TIMEFRAMES")
 
 	// If a path is provided (for local dev), also read from a config file.
 	// Environment variables will take precedence.
@@ -0,0 +1,185 @@
+// Package correlation measures how the returns of several symbols move together.
+package correlation
+
+import (
+	"math"
+	"sort"
+	"tv-bot-go/pkg/kline"
+	"tv-bot-go/pkg/stats"
+)
+
+// Align returns the open times shared by every series and the closes of each
+// symbol at those times, in chronological order.
+func Align(series map[string][]kline.Kline) (times []int64, closes map[string][]float64) {
+	if len(series) == 0 {
+		return nil, nil
+	}
+
+	counts := make(map[int64]int)
+	for _, klines := range series {
+		for _, k := range klines {
+			counts[k.OpenTime]++
+		}
+	}
+	for t, n := range counts {
+		if n == len(series) {
+			times = append(times, t)
+		}
+	}
+	sort.Slice(times, func(a, b int) bool { return times[a] < times[b] })
+
+	index := make(map[int64]int, len(times))
+	for i, t := range times {
+		index[t] = i
+	}
+	closes = make(map[string][]float64, len(series))
+	for symbol, klines := range series {
+		values := make([]float64, len(times))
+		for _, k := range klines {
+			if i, ok := index[k.OpenTime]; ok {
+				values[i] = k.Close
+			}
+		}
+		closes[symbol] = values
+	}
+	return times, closes
+}
+
+// AlignPair returns the closes of a and b at the open times both share, in
+// chronological order.
+func AlignPair(a, b []kline.Kline) (closesA, closesB []float64) {
+	_, closes := Align(map[string][]kline.Kline{"a": a, "b": b})
+	return closes["a"], closes["b"]
+}
+
+// PairReturns returns the log returns of a and b over the open times both share.
+func PairReturns(a, b []kline.Kline) (returnsA, returnsB []float64) {
+	closesA, closesB := AlignPair(a, b)
+	return stats.LogReturns(closesA), stats.LogReturns(closesB)
+}
+
+// Pearson returns the Pearson correlation coefficient of two equally long
+// series. It returns 0 if either series is constant or too short.
+func Pearson(a, b []float64) float64 {
+	n := min(len(a), len(b))
+	if n < 2 {
+		return 0
+	}
+	a, b = a[len(a)-n:], b[len(b)-n:]
+
+	meanA, meanB := stats.Mean(a), stats.Mean(b)
+	var cov, varA, varB float64
+	for i := 0; i < n; i++ {
+		da, db := a[i]-meanA, b[i]-meanB
+		cov += da * db
+		varA += da * da
+		varB += db * db
+	}
+	if varA == 0 || varB == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varA*varB)
+}
+
+// RollingPearson returns the correlation of a and b over a sliding window.
+// The result is aligned to the end of the inputs.
+func RollingPearson(a, b []float64, window int) []float64 {
+	n := min(len(a), len(b))
+	if window < 2 || n < window {
+		return nil
+	}
+	a, b = a[len(a)-n:], b[len(b)-n:]
+
+	result := make([]float64, n-window+1)
+	for i := range result {
+		result[i] = Pearson(a[i:i+window], b[i:i+window])
+	}
+	return result
+}
+
+// Beta returns the sensitivity of asset returns to benchmark returns:
+// cov(asset, benchmark) / var(benchmark).
+func Beta(asset, benchmark []float64) float64 {
+	n := min(len(asset), len(benchmark))
+	if n < 2 {
+		return 0
+	}
+	asset, benchmark = asset[len(asset)-n:], benchmark[len(benchmark)-n:]
+
+	meanA, meanB := stats.Mean(asset), stats.Mean(benchmark)
+	var cov, varB float64
+	for i := 0; i < n; i++ {
+		db := benchmark[i] - meanB
+		cov += (asset[i] - meanA) * db
+		varB += db * db
+	}
+	if varB == 0 {
+		return 0
+	}
+	return cov / varB
+}
+
+// Matrix is a symmetric correlation matrix.
+type Matrix struct {
+	Symbols []string    `json:"symbols"`
+	Values  [][]float64 `json:"values"`
+}
+
+// NewMatrix correlates every pair of return series. Symbols are sorted by name.
+func NewMatrix(returns map[string][]float64) *Matrix {
+	return newMatrix(sortedKeys(returns), func(a, b string) float64 {
+		return Pearson(returns[a], returns[b])
+	})
+}
+
+// NewPairwiseMatrix correlates the log returns of every pair of symbols over
+// the open times that pair shares, so that one symbol with a short or gappy
+// history does not shorten every other pair. Symbols are sorted by name.
+func NewPairwiseMatrix(series map[string][]kline.Kline) *Matrix {
+	return newMatrix(sortedKeys(series), func(a, b string) float64 {
+		return Pearson(PairReturns(series[a], series[b]))
+	})
+}
+
+// newMatrix fills a symmetric matrix with the correlation of every pair of symbols.
+func newMatrix(symbols []string, correlate func(a, b string) float64) *Matrix {
+	m := &Matrix{Symbols: symbols, Values: make([][]float64, len(symbols))}
+	for i := range symbols {
+		m.Values[i] = make([]float64, len(symbols))
+		m.Values[i][i] = 1
+	}
+	for i := range symbols {
+		for j := i + 1; j < len(symbols); j++ {
+			c := correlate(symbols[i], symbols[j])
+			m.Values[i][j], m.Values[j][i] = c, c
+		}
+	}
+	return m
+}
+
+// sortedKeys returns the keys of m sorted by name.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Get returns the correlation between two symbols in the matrix.
+func (m *Matrix) Get(a, b string) (float64, bool) {
+	i, j := -1, -1
+	for k, symbol := range m.Symbols {
+		if symbol == a {
+			i = k
+		}
+		if symbol == b {
+			j = k
+		}
+	}
+	if i < 0 || j < 0 {
+		return 0, false
+	}
+	return m.Values[i][j], true
+}
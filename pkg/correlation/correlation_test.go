@@ -0,0 +1,115 @@
+package correlation
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"tv-bot-go/pkg/kline"
+)
+
+// klinesAt returns klines with the given closes opening at the given times.
+func klinesAt(times []int64, closes []float64) []kline.Kline {
+	klines := make([]kline.Kline, len(times))
+	for i, t := range times {
+		klines[i] = kline.Kline{OpenTime: t, Close: closes[i]}
+	}
+	return klines
+}
+
+func TestAlign(t *testing.T) {
+	series := map[string][]kline.Kline{
+		"a": klinesAt([]int64{1, 2, 3, 4}, []float64{10, 20, 30, 40}),
+		"b": klinesAt([]int64{2, 4, 5}, []float64{2, 4, 5}),
+		"c": klinesAt([]int64{4, 2, 1}, []float64{400, 200, 100}),
+	}
+	times, closes := Align(series)
+	if want := []int64{2, 4}; !reflect.DeepEqual(times, want) {
+		t.Errorf("times = %v, want %v", times, want)
+	}
+	want := map[string][]float64{"a": {20, 40}, "b": {2, 4}, "c": {200, 400}}
+	if !reflect.DeepEqual(closes, want) {
+		t.Errorf("closes = %v, want %v", closes, want)
+	}
+
+	a, b := AlignPair(series["a"], series["c"])
+	if !reflect.DeepEqual(a, []float64{10, 20, 40}) || !reflect.DeepEqual(b, []float64{100, 200, 400}) {
+		t.Errorf("AlignPair = %v, %v", a, b)
+	}
+}
+
+func TestPearsonAndBeta(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5}
+	tests := []struct {
+		name    string
+		a, b    []float64
+		pearson float64
+		beta    float64
+	}{
+		{name: "identical", a: x, b: x, pearson: 1, beta: 1},
+		{name: "double", a: []float64{2, 4, 6, 8, 10}, b: x, pearson: 1, beta: 2},
+		{name: "inverse", a: []float64{5, 4, 3, 2, 1}, b: x, pearson: -1, beta: -1},
+		{name: "constant", a: []float64{3, 3, 3, 3, 3}, b: x, pearson: 0, beta: 0},
+		{name: "constant benchmark", a: x, b: []float64{3, 3, 3, 3, 3}, pearson: 0, beta: 0},
+		{name: "too short", a: []float64{1}, b: []float64{1}, pearson: 0, beta: 0},
+		{name: "end aligned", a: []float64{99, 1, 2, 3}, b: []float64{1, 2, 3}, pearson: 1, beta: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Pearson(tt.a, tt.b); math.Abs(got-tt.pearson) > 1e-12 {
+				t.Errorf("Pearson = %v, want %v", got, tt.pearson)
+			}
+			if got := Beta(tt.a, tt.b); math.Abs(got-tt.beta) > 1e-12 {
+				t.Errorf("Beta = %v, want %v", got, tt.beta)
+			}
+		})
+	}
+}
+
+func TestRollingPearson(t *testing.T) {
+	a := []float64{1, 2, 3, 4, 5, 4, 3}
+	b := []float64{1, 2, 3, 4, 5, 6, 7}
+	got := RollingPearson(a, b, 3)
+	want := []float64{1, 1, 1, 0, -1}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-12 {
+			t.Errorf("value %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if RollingPearson(a, b, 1) != nil || RollingPearson(a, b, 8) != nil {
+		t.Error("invalid window returned values")
+	}
+}
+
+func TestNewPairwiseMatrix(t *testing.T) {
+	long := []int64{1, 2, 3, 4, 5, 6}
+	series := map[string][]kline.Kline{
+		"a": klinesAt(long, []float64{1, 2, 4, 8, 4, 1}),
+		"b": klinesAt(long, []float64{3, 6, 12, 24, 12, 3}),
+		// c only overlaps the last three candles, which must not shorten a and b.
+		"c": klinesAt([]int64{4, 5, 6}, []float64{1, 2, 8}),
+	}
+	m := NewPairwiseMatrix(series)
+	if !reflect.DeepEqual(m.Symbols, []string{"a", "b", "c"}) {
+		t.Fatalf("symbols = %v", m.Symbols)
+	}
+	if ab, _ := m.Get("a", "b"); math.Abs(ab-1) > 1e-12 {
+		t.Errorf("a/b = %v, want 1", ab)
+	}
+	if ac, _ := m.Get("a", "c"); math.Abs(ac+1) > 1e-12 {
+		t.Errorf("a/c = %v, want -1", ac)
+	}
+	for i := range m.Symbols {
+		for j := range m.Symbols {
+			if m.Values[i][j] != m.Values[j][i] {
+				t.Errorf("matrix is not symmetric at %d,%d", i, j)
+			}
+		}
+	}
+	if _, ok := m.Get("a", "x"); ok {
+		t.Error("Get found an unknown symbol")
+	}
+}
@@ -0,0 +1,62 @@
+package events
+
+import (
+	"tv-bot-go/pkg/indicators"
+	"tv-bot-go/pkg/kline"
+)
+
+// Default is the standard set of detectors.
+var Default = []Detector{
+	MACDCrosses(12, 26, 9),
+	MFIThresholds(14, 80, 20),
+	ADXCrosses(14, 25),
+	OBVCrosses(20),
+}
+
+// MACDCrosses detects the MACD line crossing its signal line and zero.
+func MACDCrosses(fastPeriod, slowPeriod, signalPeriod int) Detector {
+	return func(klines []kline.Kline) []Event {
+		macd := indicators.CalculateMACD(kline.Closes(klines), fastPeriod, slowPeriod, signalPeriod)
+		line := make([]float64, len(macd))
+		signal := make([]float64, len(macd))
+		for i, m := range macd {
+			line[i], signal[i] = m.MACD, m.Signal
+		}
+		found := crosses(MACDSignalCross, len(klines), line, signal, "")
+		return append(found, crosses(MACDZeroCross, len(klines), line, constant(0, len(line)), "")...)
+	}
+}
+
+// MFIThresholds detects the MFI entering the overbought zone above
+// overbought and the oversold zone below oversold.
+func MFIThresholds(period int, overbought, oversold float64) Detector {
+	return func(klines []kline.Kline) []Event {
+		h, l, c, v := kline.HLCV(klines)
+		mfi := indicators.CalculateMFI(h, l, c, v, period)
+		found := crosses(MFIOverbought, len(klines), mfi, constant(overbought, len(mfi)), Up)
+		return append(found, crosses(MFIOversold, len(klines), mfi, constant(oversold, len(mfi)), Down)...)
+	}
+}
+
+// ADXCrosses detects the ADX crossing level in either direction.
+func ADXCrosses(period int, level float64) Detector {
+	return func(klines []kline.Kline) []Event {
+		h, l, c, _ := kline.HLCV(klines)
+		adx := indicators.CalculateADX(h, l, c, period)
+		if len(adx) < period {
+			return nil
+		}
+		// The first period-1 values are the warm-up of the smoothing.
+		adx = adx[period-1:]
+		return crosses(ADXThreshold, len(klines), adx, constant(level, len(adx)), "")
+	}
+}
+
+// OBVCrosses detects the OBV crossing its simple moving average.
+func OBVCrosses(period int) Detector {
+	return func(klines []kline.Kline) []Event {
+		_, _, c, v := kline.HLCV(klines)
+		obv := indicators.CalculateOBV(c, v)
+		return crosses(OBVMovingAverage, len(klines), obv, indicators.CalculateSMA(obv, period), "")
+	}
+}
@@ -0,0 +1,144 @@
+// Package events detects crossover and threshold events in indicator series.
+package events
+
+import (
+	"fmt"
+	"sort"
+	"time"
+	"tv-bot-go/pkg/kline"
+)
+
+// Type identifies the kind of event.
+type Type string
+
+const (
+	MACDSignalCross  Type = "macd_signal_cross"
+	MACDZeroCross    Type = "macd_zero_cross"
+	MFIOverbought    Type = "mfi_overbought"
+	MFIOversold      Type = "mfi_oversold"
+	ADXThreshold     Type = "adx_threshold_cross"
+	OBVMovingAverage Type = "obv_ma_cross"
+)
+
+// Direction is the direction of a cross.
+type Direction string
+
+const (
+	Up   Direction = "up"
+	Down Direction = "down"
+)
+
+// Event is a crossover or threshold event on a single kline.
+type Event struct {
+	Type      Type      `json:"type"`
+	Direction Direction `json:"direction"`
+	// OpenTime is the open time of the kline the event occurred on, in milliseconds.
+	OpenTime int64     `json:"open_time"`
+	Time     time.Time `json:"time"`
+	// Index is the position of the kline in the scanned series.
+	Index int `json:"index"`
+	// BarsAgo is the number of klines between the event and the end of the scanned series.
+	BarsAgo int `json:"bars_ago"`
+	// Value is the indicator value on the event kline and Reference the line it crossed.
+	Value     float64 `json:"value"`
+	Reference float64 `json:"reference"`
+}
+
+// Key identifies an event uniquely for a symbol and interval, so that
+// notification systems can skip events they have already sent.
+func (e Event) Key() string {
+	return fmt.Sprintf("%s:%s:%d", e.Type, e.Direction, e.OpenTime)
+}
+
+// String describes the event in plain words.
+func (e Event) String() string {
+	side := "above"
+	if e.Direction == Down {
+		side = "below"
+	}
+	switch e.Type {
+	case MACDSignalCross:
+		return fmt.Sprintf("MACD crossed %s its signal line", side)
+	case MACDZeroCross:
+		return fmt.Sprintf("MACD crossed %s zero", side)
+	case MFIOverbought:
+		return fmt.Sprintf("MFI entered overbought (%.0f > %.0f)", e.Value, e.Reference)
+	case MFIOversold:
+		return fmt.Sprintf("MFI entered oversold (%.0f < %.0f)", e.Value, e.Reference)
+	case ADXThreshold:
+		return fmt.Sprintf("ADX crossed %s %.0f", side, e.Reference)
+	case OBVMovingAverage:
+		return fmt.Sprintf("OBV crossed %s its moving average", side)
+	default:
+		return fmt.Sprintf("%s %s", e.Type, e.Direction)
+	}
+}
+
+// Detector finds the events of one kind in a kline series. Event times and
+// BarsAgo are filled in by Detect.
+type Detector func(klines []kline.Kline) []Event
+
+// Detect runs every detector over klines and returns the events ordered by
+// kline, and by detector order within a kline.
+func Detect(klines []kline.Kline, detectors ...Detector) []Event {
+	var found []Event
+	for _, detect := range detectors {
+		for _, e := range detect(klines) {
+			e.OpenTime = klines[e.Index].OpenTime
+			e.Time = time.UnixMilli(e.OpenTime).UTC()
+			e.BarsAgo = len(klines) - 1 - e.Index
+			found = append(found, e)
+		}
+	}
+	sort.SliceStable(found, func(a, b int) bool { return found[a].Index < found[b].Index })
+	return found
+}
+
+// Since returns the events that occurred on klines opening at or after t.
+func Since(events []Event, t time.Time) []Event {
+	var recent []Event
+	for _, e := range events {
+		if !e.Time.Before(t) {
+			recent = append(recent, e)
+		}
+	}
+	return recent
+}
+
+// crosses returns an event wherever series crosses ref. Both are aligned to
+// the end of a kline series of length n. only limits the result to crosses in
+// that direction if it is not empty.
+func crosses(eventType Type, n int, series, ref []float64, only Direction) []Event {
+	// Compare only the values both series have.
+	m := min(len(series), len(ref))
+	series, ref = series[len(series)-m:], ref[len(ref)-m:]
+
+	var found []Event
+	offset := n - m
+	for i := 1; i < m; i++ {
+		prevRef, currRef := ref[i-1], ref[i]
+		var dir Direction
+		switch {
+		case series[i-1] <= prevRef && series[i] > currRef:
+			dir = Up
+		case series[i-1] >= prevRef && series[i] < currRef:
+			dir = Down
+		default:
+			continue
+		}
+		if only != "" && dir != only {
+			continue
+		}
+		found = append(found, Event{Type: eventType, Direction: dir, Index: offset + i, Value: series[i], Reference: currRef})
+	}
+	return found
+}
+
+// constant returns a horizontal reference line of length n.
+func constant(value float64, n int) []float64 {
+	line := make([]float64, n)
+	for i := range line {
+		line[i] = value
+	}
+	return line
+}
@@ -1,6 +1,9 @@
 package indicators
 
-import "math"
+import (
+	"math"
+	"tv-bot-go/pkg/kline"
+)
 
 // CalculateADX calculates the Average Directional Index (ADX).
 // It requires high, low, and close prices, and a period for the calculation.
@@ -20,7 +23,11 @@ func CalculateADX(highs, lows, closes []float64, period int) []float64 {
 	}
 
 	// Smooth the DX to get ADX. We start from where DX is available.
+	// smooth keeps a running Wilder sum, so divide by period to get the average.
 	adx := smooth(dx[period-1:], period)
+	for i := range adx {
+		adx[i] /= float64(period)
+	}
 
 	return adx
 }
@@ -93,3 +100,101 @@ func smooth(data []float64, period int) []float64 {
 	// The first 'period-1' values are not valid, so we return the valid part
 	return smoothed
 }
+
+// wilderSum is the streaming counterpart of smooth.
+type wilderSum struct {
+	period int
+	count  int
+	value  float64
+}
+
+func (w *wilderSum) update(v float64) {
+	w.count++
+	if w.count <= w.period {
+		w.value += v
+		return
+	}
+	w.value = w.value - (w.value / float64(w.period)) + v
+}
+
+func (w *wilderSum) ready() bool {
+	return w.count >= w.period
+}
+
+// ADX is a streaming Average Directional Index producing the same values as CalculateADX.
+type ADX struct {
+	period                       int
+	count                        int
+	prevHigh, prevLow, prevClose float64
+	tr, dmPlus, dmMinus          wilderSum
+	adx                          wilderSum
+}
+
+// NewADX creates a streaming ADX for the given period. Periods below 1 are treated as 1.
+func NewADX(period int) *ADX {
+	period = streamingPeriod(period)
+	return &ADX{
+		period:  period,
+		tr:      wilderSum{period: period},
+		dmPlus:  wilderSum{period: period},
+		dmMinus: wilderSum{period: period},
+		adx:     wilderSum{period: period},
+	}
+}
+
+// Update feeds the next kline into the ADX.
+func (a *ADX) Update(k kline.Kline) {
+	var tr, dmPlus, dmMinus float64
+	if a.count > 0 {
+		tr = math.Max(k.High-k.Low, math.Max(math.Abs(k.High-a.prevClose), math.Abs(k.Low-a.prevClose)))
+
+		upMove := k.High - a.prevHigh
+		downMove := a.prevLow - k.Low
+		if upMove > downMove && upMove > 0 {
+			dmPlus = upMove
+		}
+		if downMove > upMove && downMove > 0 {
+			dmMinus = downMove
+		}
+	}
+	a.count++
+	a.prevHigh, a.prevLow, a.prevClose = k.High, k.Low, k.Close
+
+	a.tr.update(tr)
+	a.dmPlus.update(dmPlus)
+	a.dmMinus.update(dmMinus)
+	if !a.tr.ready() {
+		return
+	}
+
+	var diPlus, diMinus, dx float64
+	if a.tr.value != 0 {
+		diPlus = 100 * a.dmPlus.value / a.tr.value
+		diMinus = 100 * a.dmMinus.value / a.tr.value
+	}
+	if diPlus+diMinus != 0 {
+		dx = 100 * math.Abs(diPlus-diMinus) / (diPlus + diMinus)
+	}
+	a.adx.update(dx)
+}
+
+// Ready reports whether enough klines have been seen to produce an ADX value.
+func (a *ADX) Ready() bool {
+	return a.adx.ready()
+}
+
+// Value returns the latest ADX value.
+func (a *ADX) Value() float64 {
+	return a.adx.value / float64(a.period)
+}
+
+// Snapshot returns a copy of the current state.
+func (a *ADX) Snapshot() *ADX {
+	c := *a
+	return &c
+}
+
+// Restore resets the ADX to a previously taken snapshot.
+func (a *ADX) Restore(s *ADX) {
+	*a = *s
+}
@@ -0,0 +1,20 @@
+package indicators
+
+// CalculateATR calculates the Average True Range using Wilder's smoothing.
+// The result is aligned so that the last value corresponds to the last kline.
+func CalculateATR(highs, lows, closes []float64, period int) []float64 {
+	if len(highs) <= period || len(lows) != len(highs) || len(closes) != len(highs) {
+		return nil
+	}
+
+	// The first true range has no previous close, so it is skipped.
+	trueRanges := calculateTrueRange(highs, lows, closes)[1:]
+	smoothed := smooth(trueRanges, period)
+
+	atrValues := make([]float64, len(trueRanges)-period+1)
+	for i := period - 1; i < len(trueRanges); i++ {
+		atrValues[i-period+1] = smoothed[i] / float64(period)
+	}
+
+	return atrValues
+}
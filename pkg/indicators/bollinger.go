@@ -0,0 +1,45 @@
+package indicators
+
+import "math"
+
+// BollingerBands holds the calculated values for the Bollinger Bands indicator.
+type BollingerBands struct {
+	Upper     float64 `json:"upper"`
+	Middle    float64 `json:"middle"`
+	Lower     float64 `json:"lower"`
+	PercentB  float64 `json:"percent_b"`
+	Bandwidth float64 `json:"bandwidth"`
+}
+
+// CalculateBollingerBands calculates Bollinger Bands around an SMA using the
+// population standard deviation. Bandwidth is expressed relative to the middle band.
+func CalculateBollingerBands(closes []float64, period int, multiplier float64) []BollingerBands {
+	sma := calculateSMA(closes, period)
+	if sma == nil {
+		return nil
+	}
+
+	results := make([]BollingerBands, 0, len(closes)-period+1)
+	for i := period - 1; i < len(closes); i++ {
+		variance := 0.0
+		for j := i - period + 1; j <= i; j++ {
+			variance += (closes[j] - sma[i]) * (closes[j] - sma[i])
+		}
+		stdDev := math.Sqrt(variance / float64(period))
+
+		bands := BollingerBands{
+			Upper:  sma[i] + multiplier*stdDev,
+			Middle: sma[i],
+			Lower:  sma[i] - multiplier*stdDev,
+		}
+		if width := bands.Upper - bands.Lower; width != 0 {
+			bands.PercentB = (closes[i] - bands.Lower) / width
+		}
+		if bands.Middle != 0 {
+			bands.Bandwidth = (bands.Upper - bands.Lower) / bands.Middle
+		}
+		results = append(results, bands)
+	}
+
+	return results
+}
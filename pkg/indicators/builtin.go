@@ -0,0 +1,431 @@
+package indicators
+
+import (
+	"fmt"
+	"math"
+	"tv-bot-go/pkg/kline"
+)
+
+// DefaultRegistry holds every built-in indicator.
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	for _, def := range builtinDefinitions() {
+		if err := DefaultRegistry.Register(def); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// period declares an integer period parameter.
+func period(name string, def float64) Param {
+	return Param{Name: name, Default: def, Min: 1, Integer: true}
+}
+
+// factor declares a positive multiplier parameter.
+func factor(name string, def float64) Param {
+	return Param{Name: name, Default: def, Min: 0}
+}
+
+// fastBelowSlow requires the fast period at index fast to be shorter than the
+// slow period at index slow.
+func fastBelowSlow(fast, slow int) func(params []float64) error {
+	return func(p []float64) error {
+		if p[fast] >= p[slow] {
+			return fmt.Errorf("fast must be less than slow, got %g and %g", p[fast], p[slow])
+		}
+		return nil
+	}
+}
+
+func builtinDefinitions() []*Definition {
+	return []*Definition{
+		{
+			Name:        "ema",
+			Description: "Exponential Moving Average of close",
+			Params:      []Param{period("period", 20)},
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return int(p[0]) },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				return Output{"value": CalculateEMA(kline.Closes(k), int(p[0]))}
+			},
+		},
+		{
+			Name:        "sma",
+			Description: "Simple Moving Average of close",
+			Params:      []Param{period("period", 20)},
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return int(p[0]) },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				return Output{"value": CalculateSMA(kline.Closes(k), int(p[0]))}
+			},
+		},
+		{
+			Name:        "wma",
+			Description: "Weighted Moving Average of close",
+			Params:      []Param{period("period", 20)},
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return int(p[0]) },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				return Output{"value": CalculateWMA(kline.Closes(k), int(p[0]))}
+			},
+		},
+		{
+			Name:        "hma",
+			Description: "Hull Moving Average of close",
+			Params:      []Param{{Name: "period", Default: 20, Min: 2, Integer: true}},
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return int(p[0]) + int(math.Sqrt(p[0])) - 1 },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				return Output{"value": CalculateHMA(kline.Closes(k), int(p[0]))}
+			},
+		},
+		{
+			Name:        "dema",
+			Description: "Double Exponential Moving Average of close",
+			Params:      []Param{period("period", 20)},
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return 2*int(p[0]) - 1 },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				return Output{"value": CalculateDEMA(kline.Closes(k), int(p[0]))}
+			},
+		},
+		{
+			Name:        "tema",
+			Description: "Triple Exponential Moving Average of close",
+			Params:      []Param{period("period", 20)},
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return 3*int(p[0]) - 2 },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				return Output{"value": CalculateTEMA(kline.Closes(k), int(p[0]))}
+			},
+		},
+		{
+			Name:        "kama",
+			Description: "Kaufman Adaptive Moving Average of close",
+			Params:      []Param{period("period", 10), period("fast", 2), period("slow", 30)},
+			Validate:    fastBelowSlow(1, 2),
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return int(p[0]) + 1 },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				return Output{"value": CalculateKAMA(kline.Closes(k), int(p[0]), int(p[1]), int(p[2]))}
+			},
+		},
+		{
+			Name:        "alma",
+			Description: "Arnaud Legoux Moving Average of close",
+			Params:      []Param{period("period", 9), {Name: "offset", Default: 0.85, Min: 0}, {Name: "sigma", Default: 6, Min: 0.01}},
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return int(p[0]) },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				return Output{"value": CalculateALMA(kline.Closes(k), int(p[0]), p[1], p[2])}
+			},
+		},
+		{
+			Name:        "macd",
+			Description: "Moving Average Convergence Divergence",
+			Params:      []Param{period("fast", 12), period("slow", 26), period("signal", 9)},
+			Validate:    fastBelowSlow(0, 1),
+			Outputs:     []string{"macd", "signal", "histogram"},
+			Lookback:    func(p []float64) int { return int(max(p[0], p[1]) + p[2] - 1) },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				result := CalculateMACD(kline.Closes(k), int(p[0]), int(p[1]), int(p[2]))
+				out := Output{"macd": nil, "signal": nil, "histogram": nil}
+				for _, m := range result {
+					out["macd"] = append(out["macd"], m.MACD)
+					out["signal"] = append(out["signal"], m.Signal)
+					out["histogram"] = append(out["histogram"], m.Histogram)
+				}
+				return out
+			},
+		},
+		{
+			Name:        "rsi",
+			Description: "Relative Strength Index",
+			Params:      []Param{period("period", 14)},
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return int(p[0]) + 1 },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				return Output{"value": CalculateRSI(kline.Closes(k), int(p[0]))}
+			},
+		},
+		{
+			Name:        "stochrsi",
+			Description: "Stochastic RSI",
+			Params:      []Param{period("rsi", 14), period("stoch", 14), period("k", 3), period("d", 3)},
+			Outputs:     []string{"k", "d"},
+			Lookback:    func(p []float64) int { return int(p[0]+p[1]+p[2]+p[3]) - 2 },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				result := CalculateStochRSI(kline.Closes(k), int(p[0]), int(p[1]), int(p[2]), int(p[3]))
+				out := Output{"k": nil, "d": nil}
+				for _, s := range result {
+					out["k"] = append(out["k"], s.K)
+					out["d"] = append(out["d"], s.D)
+				}
+				return out
+			},
+		},
+		{
+			Name:        "cci",
+			Description: "Commodity Channel Index",
+			Params:      []Param{period("period", 20)},
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return int(p[0]) },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				h, l, c, _ := kline.HLCV(k)
+				return Output{"value": CalculateCCI(h, l, c, int(p[0]))}
+			},
+		},
+		{
+			Name:        "willr",
+			Description: "Williams %R",
+			Params:      []Param{period("period", 14)},
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return int(p[0]) },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				h, l, c, _ := kline.HLCV(k)
+				return Output{"value": CalculateWilliamsR(h, l, c, int(p[0]))}
+			},
+		},
+		{
+			Name:        "roc",
+			Description: "Rate of Change in percent",
+			Params:      []Param{period("period", 12)},
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return int(p[0]) + 1 },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				return Output{"value": CalculateROC(kline.Closes(k), int(p[0]))}
+			},
+		},
+		{
+			Name:        "adx",
+			Description: "Average Directional Index",
+			Params:      []Param{period("period", 14)},
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return 2*int(p[0]) - 1 },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				h, l, c, _ := kline.HLCV(k)
+				return Output{"value": validFrom(CalculateADX(h, l, c, int(p[0])), int(p[0])-1)}
+			},
+		},
+		{
+			Name:        "atr",
+			Description: "Average True Range",
+			Params:      []Param{period("period", 14)},
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return int(p[0]) + 1 },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				h, l, c, _ := kline.HLCV(k)
+				return Output{"value": CalculateATR(h, l, c, int(p[0]))}
+			},
+		},
+		{
+			Name:        "obv",
+			Description: "On-Balance Volume",
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return 1 },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				_, _, c, v := kline.HLCV(k)
+				return Output{"value": CalculateOBV(c, v)}
+			},
+		},
+		{
+			Name:        "mfi",
+			Description: "Money Flow Index",
+			Params:      []Param{period("period", 14)},
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return int(p[0]) + 1 },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				h, l, c, v := kline.HLCV(k)
+				return Output{"value": CalculateMFI(h, l, c, v, int(p[0]))}
+			},
+		},
+		{
+			Name:        "ad",
+			Description: "Accumulation/Distribution line",
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return 1 },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				h, l, c, v := kline.HLCV(k)
+				return Output{"value": CalculateAD(h, l, c, v)}
+			},
+		},
+		{
+			Name:        "cmf",
+			Description: "Chaikin Money Flow",
+			Params:      []Param{period("period", 20)},
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return int(p[0]) },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				h, l, c, v := kline.HLCV(k)
+				return Output{"value": CalculateCMF(h, l, c, v, int(p[0]))}
+			},
+		},
+		{
+			Name:        "chaikin",
+			Description: "Chaikin Oscillator",
+			Params:      []Param{period("fast", 3), period("slow", 10)},
+			Validate:    fastBelowSlow(0, 1),
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return int(max(p[0], p[1])) },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				h, l, c, v := kline.HLCV(k)
+				return Output{"value": CalculateChaikinOscillator(h, l, c, v, int(p[0]), int(p[1]))}
+			},
+		},
+		{
+			Name:        "force",
+			Description: "Elder Force Index",
+			Params:      []Param{period("period", 13)},
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return int(p[0]) + 1 },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				_, _, c, v := kline.HLCV(k)
+				return Output{"value": CalculateForceIndex(c, v, int(p[0]))}
+			},
+		},
+		{
+			Name:        "eom",
+			Description: "Ease of Movement",
+			Params:      []Param{period("period", 14), {Name: "scale", Default: 1e6, Min: 0}},
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return int(p[0]) + 1 },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				h, l, _, v := kline.HLCV(k)
+				return Output{"value": CalculateEaseOfMovement(h, l, v, int(p[0]), p[1])}
+			},
+		},
+		{
+			Name:        "rvol",
+			Description: "Relative volume versus the average of the preceding candles",
+			Params:      []Param{period("period", 20)},
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return int(p[0]) + 1 },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				_, _, _, v := kline.HLCV(k)
+				return Output{"value": CalculateRVOL(v, int(p[0]))}
+			},
+		},
+		{
+			Name:        "pvo",
+			Description: "Volume Oscillator in percent",
+			Params:      []Param{period("fast", 5), period("slow", 10)},
+			Validate:    fastBelowSlow(0, 1),
+			Outputs:     []string{"value"},
+			Lookback:    func(p []float64) int { return int(max(p[0], p[1])) },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				_, _, _, v := kline.HLCV(k)
+				return Output{"value": CalculateVolumeOscillator(v, int(p[0]), int(p[1]))}
+			},
+		},
+		{
+			Name:        "bb",
+			Description: "Bollinger Bands",
+			Params:      []Param{period("period", 20), factor("multiplier", 2)},
+			Outputs:     []string{"upper", "middle", "lower", "percent_b", "bandwidth"},
+			Lookback:    func(p []float64) int { return int(p[0]) },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				result := CalculateBollingerBands(kline.Closes(k), int(p[0]), p[1])
+				out := Output{"upper": nil, "middle": nil, "lower": nil, "percent_b": nil, "bandwidth": nil}
+				for _, b := range result {
+					out["upper"] = append(out["upper"], b.Upper)
+					out["middle"] = append(out["middle"], b.Middle)
+					out["lower"] = append(out["lower"], b.Lower)
+					out["percent_b"] = append(out["percent_b"], b.PercentB)
+					out["bandwidth"] = append(out["bandwidth"], b.Bandwidth)
+				}
+				return out
+			},
+		},
+		{
+			Name:        "kc",
+			Description: "Keltner Channels",
+			Params:      []Param{period("ema", 20), period("atr", 10), factor("multiplier", 1.5)},
+			Outputs:     []string{"upper", "middle", "lower"},
+			Lookback:    func(p []float64) int { return int(max(p[0], p[1]+1)) },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				h, l, c, _ := kline.HLCV(k)
+				result := CalculateKeltnerChannels(h, l, c, int(p[0]), int(p[1]), p[2])
+				out := Output{"upper": nil, "middle": nil, "lower": nil}
+				for _, ch := range result {
+					out["upper"] = append(out["upper"], ch.Upper)
+					out["middle"] = append(out["middle"], ch.Middle)
+					out["lower"] = append(out["lower"], ch.Lower)
+				}
+				return out
+			},
+		},
+		{
+			Name:        "supertrend",
+			Description: "Supertrend trailing stop",
+			Params:      []Param{period("period", 10), factor("multiplier", 3)},
+			Outputs:     []string{"value", "direction"},
+			Lookback:    func(p []float64) int { return int(p[0]) + 1 },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				h, l, c, _ := kline.HLCV(k)
+				return trendStopOutput(CalculateSupertrend(h, l, c, int(p[0]), p[1]), int(p[0]))
+			},
+		},
+		{
+			Name:        "psar",
+			Description: "Parabolic SAR",
+			Params:      []Param{factor("step", 0.02), factor("max", 0.2)},
+			Outputs:     []string{"value", "direction"},
+			Lookback:    func(p []float64) int { return 2 },
+			Validate: func(p []float64) error {
+				if p[0] > p[1] {
+					return fmt.Errorf("step must not exceed max, got %g and %g", p[0], p[1])
+				}
+				return nil
+			},
+			Compute: func(k []kline.Kline, p []float64) Output {
+				h, l, _, _ := kline.HLCV(k)
+				return trendStopOutput(CalculateParabolicSAR(h, l, p[0], p[1]), 1)
+			},
+		},
+		{
+			Name:        "ichimoku",
+			Description: "Ichimoku Kinko Hyo (current cloud)",
+			Params:      []Param{period("tenkan", 9), period("kijun", 26), period("senkou_b", 52), period("displacement", 26)},
+			Outputs:     []string{"tenkan", "kijun", "senkou_a", "senkou_b"},
+			Lookback:    func(p []float64) int { return int(max(p[0], p[1], p[2]) + p[3]) },
+			Compute: func(k []kline.Kline, p []float64) Output {
+				h, l, c, _ := kline.HLCV(k)
+				series := CalculateIchimoku(h, l, c, int(p[0]), int(p[1]), int(p[2]), int(p[3]))
+				if series == nil {
+					return Output{}
+				}
+				start := series.senkouStart
+				return Output{
+					"tenkan":   validFrom(series.Tenkan, start),
+					"kijun":    validFrom(series.Kijun, start),
+					"senkou_a": validFrom(series.SenkouA[:len(c)], start),
+					"senkou_b": validFrom(series.SenkouB[:len(c)], start),
+				}
+			},
+		},
+	}
+}
+
+// trendStopOutput converts a trend stop into output series, dropping the warm-up.
+func trendStopOutput(stop *TrendStop, start int) Output {
+	if stop == nil {
+		return Output{}
+	}
+	directions := make([]float64, len(stop.Directions))
+	for i, d := range stop.Directions {
+		directions[i] = float64(d)
+	}
+	return Output{
+		"value":     validFrom(stop.Values, start),
+		"direction": validFrom(directions, start),
+	}
+}
+
+// validFrom drops the warm-up values before start from a full-length series.
+func validFrom(series []float64, start int) []float64 {
+	if start >= len(series) {
+		return nil
+	}
+	return series[max(start, 0):]
+}
@@ -0,0 +1,33 @@
+package indicators
+
+import "math"
+
+// CalculateCCI calculates the Commodity Channel Index.
+// It requires high, low, and close prices, and a period for the calculation.
+func CalculateCCI(highs, lows, closes []float64, period int) []float64 {
+	if len(closes) < period || len(highs) != len(closes) || len(lows) != len(closes) {
+		return nil
+	}
+
+	typicalPrices := make([]float64, len(closes))
+	for i := range closes {
+		typicalPrices[i] = (highs[i] + lows[i] + closes[i]) / 3
+	}
+	sma := calculateSMA(typicalPrices, period)
+
+	cciValues := make([]float64, len(closes)-period+1)
+	for i := period - 1; i < len(closes); i++ {
+		meanDeviation := 0.0
+		for j := i - period + 1; j <= i; j++ {
+			meanDeviation += math.Abs(typicalPrices[j] - sma[i])
+		}
+		meanDeviation /= float64(period)
+
+		if meanDeviation == 0 {
+			continue
+		}
+		cciValues[i-period+1] = (typicalPrices[i] - sma[i]) / (0.015 * meanDeviation)
+	}
+
+	return cciValues
+}
@@ -0,0 +1,104 @@
+package indicators
+
+// DivergenceKind classifies a divergence between price and an indicator.
+type DivergenceKind string
+
+const (
+	// RegularBullish is a lower low in price with a higher low in the indicator.
+	RegularBullish DivergenceKind = "regular_bullish"
+	// HiddenBullish is a higher low in price with a lower low in the indicator.
+	HiddenBullish DivergenceKind = "hidden_bullish"
+	// RegularBearish is a higher high in price with a lower high in the indicator.
+	RegularBearish DivergenceKind = "regular_bearish"
+	// HiddenBearish is a lower high in price with a higher high in the indicator.
+	HiddenBearish DivergenceKind = "hidden_bearish"
+)
+
+// DivergencePivot is a swing point used in a divergence.
+type DivergencePivot struct {
+	Index     int     `json:"index"`
+	Price     float64 `json:"price"`
+	Indicator float64 `json:"indicator"`
+}
+
+// Divergence is a disagreement between two consecutive price swings and the
+// indicator values at the same klines.
+type Divergence struct {
+	Indicator string          `json:"indicator"`
+	Kind      DivergenceKind  `json:"kind"`
+	From      DivergencePivot `json:"from"`
+	To        DivergencePivot `json:"to"`
+	// BarsAgo is the number of klines between the second pivot and the last kline.
+	BarsAgo int `json:"bars_ago"`
+}
+
+// DivergenceConfig controls swing detection for FindDivergences.
+type DivergenceConfig struct {
+	// Left and Right are the number of klines on each side that a swing must beat.
+	Left, Right int
+	// MinDistance and MaxDistance bound the number of klines between the two pivots.
+	MinDistance, MaxDistance int
+}
+
+// DefaultDivergenceConfig is a reasonable configuration for intraday timeframes.
+var DefaultDivergenceConfig = DivergenceConfig{Left: 3, Right: 3, MinDistance: 5, MaxDistance: 60}
+
+// FindDivergences compares consecutive swing highs and lows in price with the
+// indicator values at the same klines. The indicator series may be shorter than
+// the price series; it is assumed to be aligned to the end.
+func FindDivergences(name string, highs, lows, indicator []float64, cfg DivergenceConfig) []Divergence {
+	if len(indicator) == 0 || len(highs) != len(lows) || len(indicator) > len(highs) {
+		return nil
+	}
+
+	offset := len(highs) - len(indicator)
+	pivot := func(prices []float64, i int) DivergencePivot {
+		return DivergencePivot{Index: i, Price: prices[i], Indicator: indicator[i-offset]}
+	}
+
+	var divergences []Divergence
+	add := func(kind DivergenceKind, from, to DivergencePivot) {
+		divergences = append(divergences, Divergence{
+			Indicator: name,
+			Kind:      kind,
+			From:      from,
+			To:        to,
+			BarsAgo:   len(highs) - 1 - to.Index,
+		})
+	}
+
+	eachPair(FindSwingLows(lows, cfg.Left, cfg.Right), offset, cfg, func(a, b int) {
+		from, to := pivot(lows, a), pivot(lows, b)
+		switch {
+		case to.Price < from.Price && to.Indicator > from.Indicator:
+			add(RegularBullish, from, to)
+		case to.Price > from.Price && to.Indicator < from.Indicator:
+			add(HiddenBullish, from, to)
+		}
+	})
+	eachPair(FindSwingHighs(highs, cfg.Left, cfg.Right), offset, cfg, func(a, b int) {
+		from, to := pivot(highs, a), pivot(highs, b)
+		switch {
+		case to.Price > from.Price && to.Indicator < from.Indicator:
+			add(RegularBearish, from, to)
+		case to.Price < from.Price && to.Indicator > from.Indicator:
+			add(HiddenBearish, from, to)
+		}
+	})
+
+	return divergences
+}
+
+// eachPair calls fn for consecutive swings that both have indicator values and
+// are within the configured distance of each other.
+func eachPair(swings []int, offset int, cfg DivergenceConfig, fn func(a, b int)) {
+	for i := 1; i < len(swings); i++ {
+		a, b := swings[i-1], swings[i]
+		if a < offset {
+			continue
+		}
+		if distance := b - a; distance >= cfg.MinDistance && distance <= cfg.MaxDistance {
+			fn(a, b)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package indicators
+
+// IchimokuSeries holds the Ichimoku Kinko Hyo lines. Tenkan and Kijun have one
+// value per kline. SenkouA and SenkouB are shifted forward by the displacement,
+// so they extend displacement values beyond the last kline. Chikou is the close
+// shifted back by the displacement, so it ends displacement values early.
+// Values before a line's warm-up period are left as zero.
+type IchimokuSeries struct {
+	Tenkan       []float64
+	Kijun        []float64
+	SenkouA      []float64
+	SenkouB      []float64
+	Chikou       []float64
+	Displacement int
+	// senkouStart is the first index at which both Senkou spans are valid.
+	senkouStart int
+}
+
+// IchimokuState classifies the Ichimoku picture on the latest kline.
+type IchimokuState struct {
+	Tenkan  float64 `json:"tenkan"`
+	Kijun   float64 `json:"kijun"`
+	SenkouA float64 `json:"senkou_a"`
+	SenkouB float64 `json:"senkou_b"`
+	// PriceVsCloud is "above", "below" or "inside".
+	PriceVsCloud string `json:"price_vs_cloud"`
+	// TKCross is the direction of the most recent Tenkan/Kijun cross ("bullish", "bearish" or "none").
+	TKCross        string `json:"tk_cross"`
+	TKCrossBarsAgo int    `json:"tk_cross_bars_ago"`
+	// CloudColor is the color of the projected cloud on the latest Senkou values ("bullish" or "bearish").
+	CloudColor string `json:"cloud_color"`
+	// CloudTwist is the direction of the nearest twist within the projected cloud ("bullish", "bearish" or "none").
+	CloudTwist     string `json:"cloud_twist"`
+	TwistBarsAhead int    `json:"twist_bars_ahead,omitempty"`
+	// ChikouVsPrice compares the latest close with the close displacement bars ago ("above" or "below").
+	ChikouVsPrice string `json:"chikou_vs_price"`
+}
+
+// CalculateIchimoku calculates the Ichimoku lines using the given Tenkan, Kijun
+// and Senkou B periods and displacement (traditionally 9, 26, 52 and 26).
+func CalculateIchimoku(highs, lows, closes []float64, tenkanPeriod, kijunPeriod, senkouBPeriod, displacement int) *IchimokuSeries {
+	if len(closes) < senkouBPeriod || len(closes) < kijunPeriod || len(closes) <= displacement {
+		return nil
+	}
+
+	series := &IchimokuSeries{
+		Tenkan:       midpoints(highs, lows, tenkanPeriod),
+		Kijun:        midpoints(highs, lows, kijunPeriod),
+		SenkouA:      make([]float64, len(closes)+displacement),
+		SenkouB:      make([]float64, len(closes)+displacement),
+		Chikou:       make([]float64, len(closes)-displacement),
+		Displacement: displacement,
+		senkouStart:  max(tenkanPeriod, kijunPeriod, senkouBPeriod) - 1 + displacement,
+	}
+
+	senkouB := midpoints(highs, lows, senkouBPeriod)
+	for i := range closes {
+		if i >= max(tenkanPeriod, kijunPeriod)-1 {
+			series.SenkouA[i+displacement] = (series.Tenkan[i] + series.Kijun[i]) / 2
+		}
+		if i >= senkouBPeriod-1 {
+			series.SenkouB[i+displacement] = senkouB[i]
+		}
+	}
+	copy(series.Chikou, closes[displacement:])
+
+	return series
+}
+
+// ClassifyIchimoku summarizes an Ichimoku series against the latest close.
+func ClassifyIchimoku(series *IchimokuSeries, closes []float64) *IchimokuState {
+	last := len(closes) - 1
+	if series == nil || last < series.senkouStart || last < series.Displacement {
+		return nil
+	}
+
+	price := closes[last]
+	state := &IchimokuState{
+		Tenkan:  series.Tenkan[last],
+		Kijun:   series.Kijun[last],
+		SenkouA: series.SenkouA[last],
+		SenkouB: series.SenkouB[last],
+	}
+
+	cloudTop := max(state.SenkouA, state.SenkouB)
+	cloudBottom := min(state.SenkouA, state.SenkouB)
+	switch {
+	case price > cloudTop:
+		state.PriceVsCloud = "above"
+	case price < cloudBottom:
+		state.PriceVsCloud = "below"
+	default:
+		state.PriceVsCloud = "inside"
+	}
+
+	state.TKCross = "none"
+	for i := last; i > 0 && series.Kijun[i-1] != 0; i-- {
+		prev := series.Tenkan[i-1] - series.Kijun[i-1]
+		curr := series.Tenkan[i] - series.Kijun[i]
+		if prev <= 0 && curr > 0 {
+			state.TKCross, state.TKCrossBarsAgo = "bullish", last-i
+			break
+		}
+		if prev >= 0 && curr < 0 {
+			state.TKCross, state.TKCrossBarsAgo = "bearish", last-i
+			break
+		}
+	}
+
+	// The projected cloud runs from the latest kline to the end of the Senkou spans.
+	end := len(series.SenkouA) - 1
+	state.CloudColor = cloudColor(series.SenkouA[end], series.SenkouB[end])
+	state.CloudTwist = "none"
+	for i := last + 1; i <= end; i++ {
+		prev := cloudColor(series.SenkouA[i-1], series.SenkouB[i-1])
+		if curr := cloudColor(series.SenkouA[i], series.SenkouB[i]); curr != prev {
+			state.CloudTwist, state.TwistBarsAhead = curr, i-last
+			break
+		}
+	}
+
+	if price >= closes[last-series.Displacement] {
+		state.ChikouVsPrice = "above"
+	} else {
+		state.ChikouVsPrice = "below"
+	}
+
+	return state
+}
+
+// cloudColor returns "bullish" when Senkou A is above Senkou B, otherwise "bearish".
+func cloudColor(senkouA, senkouB float64) string {
+	if senkouA >= senkouB {
+		return "bullish"
+	}
+	return "bearish"
+}
+
+// midpoints returns the midpoint of the highest high and lowest low over each window.
+// The first period-1 values are left as zero.
+func midpoints(highs, lows []float64, period int) []float64 {
+	result := make([]float64, len(highs))
+	for i := period - 1; i < len(highs); i++ {
+		_, highest := minMax(highs[i-period+1 : i+1])
+		lowest, _ := minMax(lows[i-period+1 : i+1])
+		result[i] = (highest + lowest) / 2
+	}
+	return result
+}
@@ -0,0 +1,33 @@
+package indicators
+
+// KeltnerChannel holds the calculated values for the Keltner Channel indicator.
+type KeltnerChannel struct {
+	Upper  float64 `json:"upper"`
+	Middle float64 `json:"middle"`
+	Lower  float64 `json:"lower"`
+}
+
+// CalculateKeltnerChannels calculates Keltner Channels as an EMA of closes
+// offset by a multiple of the ATR.
+func CalculateKeltnerChannels(highs, lows, closes []float64, emaPeriod, atrPeriod int, multiplier float64) []KeltnerChannel {
+	ema := calculateEMA(closes, emaPeriod)
+	atr := CalculateATR(highs, lows, closes, atrPeriod)
+	if ema == nil || atr == nil {
+		return nil
+	}
+
+	// Both series are aligned to the end; use the shorter of the two.
+	length := min(len(closes)-emaPeriod+1, len(atr))
+	results := make([]KeltnerChannel, length)
+	for i := 0; i < length; i++ {
+		middle := ema[len(ema)-length+i]
+		offset := multiplier * atr[len(atr)-length+i]
+		results[i] = KeltnerChannel{
+			Upper:  middle + offset,
+			Middle: middle,
+			Lower:  middle - offset,
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,157 @@
+package indicators
+
+import "math"
+
+// The moving averages in this file share the same warm-up semantics: only
+// complete values are returned, and the result is aligned so that the last
+// value corresponds to the last input. A result of length n therefore starts
+// at input index len(data)-n. They return nil when data is too short.
+
+// CalculateSMA calculates the Simple Moving Average.
+func CalculateSMA(data []float64, period int) []float64 {
+	return validFrom(calculateSMA(data, period), period-1)
+}
+
+// CalculateEMA calculates the Exponential Moving Average, seeded with the SMA
+// of the first period values.
+func CalculateEMA(data []float64, period int) []float64 {
+	if period <= 0 {
+		return nil
+	}
+	return validFrom(calculateEMA(data, period), period-1)
+}
+
+// CalculateWMA calculates the linearly Weighted Moving Average, giving the
+// most recent value a weight of period.
+func CalculateWMA(data []float64, period int) []float64 {
+	if period <= 0 || len(data) < period {
+		return nil
+	}
+
+	denominator := float64(period*(period+1)) / 2
+	wma := make([]float64, len(data)-period+1)
+	for i := period - 1; i < len(data); i++ {
+		sum := 0.0
+		for j := 0; j < period; j++ {
+			sum += data[i-period+1+j] * float64(j+1)
+		}
+		wma[i-period+1] = sum / denominator
+	}
+	return wma
+}
+
+// CalculateHMA calculates the Hull Moving Average:
+// WMA(2*WMA(period/2) - WMA(period), sqrt(period)).
+func CalculateHMA(data []float64, period int) []float64 {
+	if period < 2 {
+		return nil
+	}
+
+	half := CalculateWMA(data, period/2)
+	full := CalculateWMA(data, period)
+	if full == nil {
+		return nil
+	}
+
+	raw := make([]float64, len(full))
+	offset := len(half) - len(full)
+	for i := range full {
+		raw[i] = 2*half[i+offset] - full[i]
+	}
+	return CalculateWMA(raw, int(math.Sqrt(float64(period))))
+}
+
+// CalculateDEMA calculates the Double Exponential Moving Average: 2*EMA - EMA(EMA).
+func CalculateDEMA(data []float64, period int) []float64 {
+	ema1 := CalculateEMA(data, period)
+	ema2 := CalculateEMA(ema1, period)
+	if ema2 == nil {
+		return nil
+	}
+
+	offset := len(ema1) - len(ema2)
+	dema := make([]float64, len(ema2))
+	for i := range ema2 {
+		dema[i] = 2*ema1[i+offset] - ema2[i]
+	}
+	return dema
+}
+
+// CalculateTEMA calculates the Triple Exponential Moving Average:
+// 3*EMA - 3*EMA(EMA) + EMA(EMA(EMA)).
+func CalculateTEMA(data []float64, period int) []float64 {
+	ema1 := CalculateEMA(data, period)
+	ema2 := CalculateEMA(ema1, period)
+	ema3 := CalculateEMA(ema2, period)
+	if ema3 == nil {
+		return nil
+	}
+
+	offset1 := len(ema1) - len(ema3)
+	offset2 := len(ema2) - len(ema3)
+	tema := make([]float64, len(ema3))
+	for i := range ema3 {
+		tema[i] = 3*ema1[i+offset1] - 3*ema2[i+offset2] + ema3[i]
+	}
+	return tema
+}
+
+// CalculateKAMA calculates Kaufman's Adaptive Moving Average. The efficiency
+// ratio over period scales the smoothing constant between the fast and slow
+// EMA periods (traditionally 10, 2 and 30).
+func CalculateKAMA(data []float64, period, fastPeriod, slowPeriod int) []float64 {
+	if period <= 0 || len(data) < period+1 {
+		return nil
+	}
+
+	fastSC := 2.0 / float64(fastPeriod+1)
+	slowSC := 2.0 / float64(slowPeriod+1)
+
+	kama := make([]float64, len(data)-period)
+	kama[0] = data[period]
+	for i := period + 1; i < len(data); i++ {
+		change := math.Abs(data[i] - data[i-period])
+		volatility := 0.0
+		for j := i - period + 1; j <= i; j++ {
+			volatility += math.Abs(data[j] - data[j-1])
+		}
+
+		efficiencyRatio := 0.0
+		if volatility != 0 {
+			efficiencyRatio = change / volatility
+		}
+		sc := math.Pow(efficiencyRatio*(fastSC-slowSC)+slowSC, 2)
+
+		prev := kama[i-period-1]
+		kama[i-period] = prev + sc*(data[i]-prev)
+	}
+	return kama
+}
+
+// CalculateALMA calculates the Arnaud Legoux Moving Average, a Gaussian-weighted
+// average whose peak sits at offset (0 to 1) across the window and whose width
+// is set by sigma (traditionally 0.85 and 6).
+func CalculateALMA(data []float64, period int, offset, sigma float64) []float64 {
+	if period <= 0 || len(data) < period || sigma <= 0 {
+		return nil
+	}
+
+	m := offset * float64(period-1)
+	s := float64(period) / sigma
+	weights := make([]float64, period)
+	weightSum := 0.0
+	for j := range weights {
+		weights[j] = math.Exp(-(float64(j) - m) * (float64(j) - m) / (2 * s * s))
+		weightSum += weights[j]
+	}
+
+	alma := make([]float64, len(data)-period+1)
+	for i := period - 1; i < len(data); i++ {
+		sum := 0.0
+		for j, w := range weights {
+			sum += data[i-period+1+j] * w
+		}
+		alma[i-period+1] = sum / weightSum
+	}
+	return alma
+}
@@ -1,5 +1,7 @@
 package indicators
 
+import "tv-bot-go/pkg/kline"
+
 // MACDIndicator holds the calculated values for the MACD indicator.
 type MACDIndicator struct {
 	MACD      float64 `json:"macd"`
@@ -74,3 +76,112 @@ func CalculateMACD(prices []float64, fastPeriod, slowPeriod, signalPeriod int) [
 
 	return results
 }
+
+// streamingPeriod clamps the period of a streaming indicator to at least 1,
+// since its buffers and divisions need at least one value.
+func streamingPeriod(period int) int {
+	return max(period, 1)
+}
+
+// EMA is a streaming Exponential Moving Average. It is seeded with the SMA of
+// the first period values, matching calculateEMA.
+type EMA struct {
+	period int
+	k      float64
+	count  int
+	sum    float64
+	value  float64
+}
+
+// NewEMA creates a streaming EMA for the given period. Periods below 1 are treated as 1.
+func NewEMA(period int) *EMA {
+	period = streamingPeriod(period)
+	return &EMA{period: period, k: 2.0 / float64(period+1)}
+}
+
+// Update feeds the next value into the EMA and returns the current average.
+func (e *EMA) Update(v float64) float64 {
+	e.count++
+	switch {
+	case e.count < e.period:
+		e.sum += v
+	case e.count == e.period:
+		e.sum += v
+		e.value = e.sum / float64(e.period)
+	default:
+		e.value = (v * e.k) + (e.value * (1 - e.k))
+	}
+	return e.value
+}
+
+// Ready reports whether enough values have been seen to produce an average.
+func (e *EMA) Ready() bool {
+	return e.count >= e.period
+}
+
+// Value returns the current average, or 0 if the EMA is not ready yet.
+func (e *EMA) Value() float64 {
+	return e.value
+}
+
+// Snapshot returns a copy of the current state.
+func (e *EMA) Snapshot() *EMA {
+	c := *e
+	return &c
+}
+
+// Restore resets the EMA to a previously taken snapshot.
+func (e *EMA) Restore(s *EMA) {
+	*e = *s
+}
+
+// MACD is a streaming MACD indicator producing the same values as CalculateMACD.
+type MACD struct {
+	fast, slow, signal EMA
+	macd               float64
+}
+
+// NewMACD creates a streaming MACD with the given periods. Periods below 1 are treated as 1.
+func NewMACD(fastPeriod, slowPeriod, signalPeriod int) *MACD {
+	return &MACD{
+		fast:   *NewEMA(fastPeriod),
+		slow:   *NewEMA(slowPeriod),
+		signal: *NewEMA(signalPeriod),
+	}
+}
+
+// Update feeds the next kline's close into the MACD.
+func (m *MACD) Update(k kline.Kline) {
+	m.fast.Update(k.Close)
+	m.slow.Update(k.Close)
+	if !m.slow.Ready() {
+		return
+	}
+	m.macd = m.fast.Value() - m.slow.Value()
+	m.signal.Update(m.macd)
+}
+
+// Ready reports whether the signal line has been established.
+func (m *MACD) Ready() bool {
+	return m.signal.Ready()
+}
+
+// Value returns the latest MACD values.
+func (m *MACD) Value() MACDIndicator {
+	return MACDIndicator{
+		MACD:      m.macd,
+		Signal:    m.signal.Value(),
+		Histogram: m.macd - m.signal.Value(),
+	}
+}
+
+// Snapshot returns a copy of the current state.
+func (m *MACD) Snapshot() *MACD {
+	c := *m
+	return &c
+}
+
+// Restore resets the MACD to a previously taken snapshot.
+func (m *MACD) Restore(s *MACD) {
+	*m = *s
+}
@@ -1,5 +1,7 @@
 package indicators
 
+import "tv-bot-go/pkg/kline"
+
 // CalculateMFI calculates the Money Flow Index (MFI).
 // It requires high, low, close prices, and volumes, along with a period.
 func CalculateMFI(highs, lows, closes, volumes []float64, period int) []float64 {
@@ -50,3 +52,83 @@ func CalculateMFI(highs, lows, closes, volumes []float64, period int) []float64
 
 	return mfiValues
 }
+
+// MFI is a streaming Money Flow Index producing the same values as CalculateMFI.
+type MFI struct {
+	period       int
+	count        int
+	prevTypical  float64
+	positiveFlow []float64
+	negativeFlow []float64
+	value        float64
+}
+
+// NewMFI creates a streaming MFI for the given period. Periods below 1 are treated as 1.
+func NewMFI(period int) *MFI {
+	period = streamingPeriod(period)
+	return &MFI{
+		period:       period,
+		positiveFlow: make([]float64, period),
+		negativeFlow: make([]float64, period),
+	}
+}
+
+// Update feeds the next kline into the MFI.
+func (m *MFI) Update(k kline.Kline) {
+	typicalPrice := (k.High + k.Low + k.Close) / 3
+	rawMoneyFlow := typicalPrice * k.Volume
+
+	slot := m.count % m.period
+	m.positiveFlow[slot], m.negativeFlow[slot] = 0, 0
+	if m.count > 0 {
+		if typicalPrice > m.prevTypical {
+			m.positiveFlow[slot] = rawMoneyFlow
+		} else if typicalPrice < m.prevTypical {
+			m.negativeFlow[slot] = rawMoneyFlow
+		}
+	}
+	m.prevTypical = typicalPrice
+	m.count++
+
+	if m.count <= m.period {
+		return
+	}
+
+	// Sum oldest to newest so the result matches the batch calculation exactly.
+	sumPositiveMF := 0.0
+	sumNegativeMF := 0.0
+	for j := m.count - m.period; j < m.count; j++ {
+		sumPositiveMF += m.positiveFlow[j%m.period]
+		sumNegativeMF += m.negativeFlow[j%m.period]
+	}
+
+	if sumNegativeMF == 0 {
+		m.value = 100
+		return
+	}
+	moneyFlowRatio := sumPositiveMF / sumNegativeMF
+	m.value = 100 - (100 / (1 + moneyFlowRatio))
+}
+
+// Ready reports whether more than period klines have been seen.
+func (m *MFI) Ready() bool {
+	return m.count > m.period
+}
+
+// Value returns the latest MFI value.
+func (m *MFI) Value() float64 {
+	return m.value
+}
+
+// Snapshot returns a deep copy of the current state.
+func (m *MFI) Snapshot() *MFI {
+	c := *m
+	c.positiveFlow = append([]float64(nil), m.positiveFlow...)
+	c.negativeFlow = append([]float64(nil), m.negativeFlow...)
+	return &c
+}
+
+// Restore resets the MFI to a previously taken snapshot.
+func (m *MFI) Restore(s *MFI) {
+	*m = *s.Snapshot()
+}
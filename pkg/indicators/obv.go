@@ -1,5 +1,7 @@
 package indicators
 
+import "tv-bot-go/pkg/kline"
+
 // CalculateOBV calculates the On-Balance Volume (OBV).
 // It requires closing prices and volumes.
 func CalculateOBV(closes, volumes []float64) []float64 {
@@ -22,3 +24,49 @@ func CalculateOBV(closes, volumes []float64) []float64 {
 
 	return obv
 }
+
+// OBV is a streaming On-Balance Volume producing the same values as CalculateOBV.
+type OBV struct {
+	count     int
+	prevClose float64
+	value     float64
+}
+
+// NewOBV creates a streaming OBV.
+func NewOBV() *OBV {
+	return &OBV{}
+}
+
+// Update feeds the next kline into the OBV.
+func (o *OBV) Update(k kline.Kline) {
+	if o.count > 0 {
+		if k.Close > o.prevClose {
+			o.value += k.Volume
+		} else if k.Close < o.prevClose {
+			o.value -= k.Volume
+		}
+	}
+	o.count++
+	o.prevClose = k.Close
+}
+
+// Ready reports whether at least one kline has been seen.
+func (o *OBV) Ready() bool {
+	return o.count > 0
+}
+
+// Value returns the latest OBV value.
+func (o *OBV) Value() float64 {
+	return o.value
+}
+
+// Snapshot returns a copy of the current state.
+func (o *OBV) Snapshot() *OBV {
+	c := *o
+	return &c
+}
+
+// Restore resets the OBV to a previously taken snapshot.
+func (o *OBV) Restore(s *OBV) {
+	*o = *s
+}
@@ -0,0 +1,52 @@
+package indicators
+
+import "tv-bot-go/pkg/kline"
+
+// CalculateVolumeDelta calculates the per-kline volume delta: taker buy volume
+// minus taker sell volume, in base asset units.
+func CalculateVolumeDelta(klines []kline.Kline) []float64 {
+	if len(klines) == 0 {
+		return nil
+	}
+
+	delta := make([]float64, len(klines))
+	for i, k := range klines {
+		takerSell := k.Volume - k.TakerBuyBaseAssetVolume
+		delta[i] = k.TakerBuyBaseAssetVolume - takerSell
+	}
+	return delta
+}
+
+// CalculateCVD calculates the Cumulative Volume Delta, starting from zero at
+// the first kline.
+func CalculateCVD(klines []kline.Kline) []float64 {
+	delta := CalculateVolumeDelta(klines)
+	if delta == nil {
+		return nil
+	}
+
+	cvd := make([]float64, len(delta))
+	cvd[0] = delta[0]
+	for i := 1; i < len(delta); i++ {
+		cvd[i] = cvd[i-1] + delta[i]
+	}
+	return cvd
+}
+
+// CalculateTakerBuyRatio calculates the share of each kline's volume bought by
+// takers, between 0 and 1. Klines without volume get a neutral 0.5.
+func CalculateTakerBuyRatio(klines []kline.Kline) []float64 {
+	if len(klines) == 0 {
+		return nil
+	}
+
+	ratio := make([]float64, len(klines))
+	for i, k := range klines {
+		if k.Volume == 0 {
+			ratio[i] = 0.5
+			continue
+		}
+		ratio[i] = k.TakerBuyBaseAssetVolume / k.Volume
+	}
+	return ratio
+}
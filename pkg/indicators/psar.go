@@ -0,0 +1,52 @@
+package indicators
+
+// CalculateParabolicSAR calculates Wilder's Parabolic Stop and Reverse.
+// The acceleration factor starts at step, grows by step on every new extreme
+// point and is capped at maxStep (traditionally 0.02 and 0.2).
+func CalculateParabolicSAR(highs, lows []float64, step, maxStep float64) *TrendStop {
+	if len(highs) < 2 || len(lows) != len(highs) {
+		return nil
+	}
+
+	result := newTrendStop(len(highs))
+
+	// Seed the initial trend from the directional movement of the second kline.
+	up := highs[1]-highs[0] >= lows[0]-lows[1]
+	sar, extreme := highs[0], lows[1]
+	if up {
+		sar, extreme = lows[0], highs[1]
+	}
+	af := step
+	result.set(1, sar, trendDirection(up))
+
+	for i := 2; i < len(highs); i++ {
+		sar += af * (extreme - sar)
+
+		if up {
+			sar = min(sar, lows[i-1], lows[i-2])
+			if lows[i] < sar {
+				up, sar, extreme, af = false, extreme, lows[i], step
+			} else if highs[i] > extreme {
+				extreme, af = highs[i], min(af+step, maxStep)
+			}
+		} else {
+			sar = max(sar, highs[i-1], highs[i-2])
+			if highs[i] > sar {
+				up, sar, extreme, af = true, extreme, highs[i], step
+			} else if lows[i] < extreme {
+				extreme, af = lows[i], min(af+step, maxStep)
+			}
+		}
+
+		result.set(i, sar, trendDirection(up))
+	}
+
+	return result
+}
+
+func trendDirection(up bool) int {
+	if up {
+		return 1
+	}
+	return -1
+}
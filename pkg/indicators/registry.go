@@ -0,0 +1,134 @@
+package indicators
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"tv-bot-go/pkg/kline"
+)
+
+// Param describes a numeric indicator parameter.
+type Param struct {
+	Name    string
+	Default float64
+	// Min is the smallest accepted value.
+	Min float64
+	// Integer requires the value to be a whole number, e.g. a period.
+	Integer bool
+}
+
+// Output holds the named output series of an indicator. Every series is
+// aligned so that its last value corresponds to the last kline.
+type Output map[string][]float64
+
+// Definition declares an indicator that can be referenced by spec.
+type Definition struct {
+	Name        string
+	Description string
+	Params      []Param
+	// Outputs lists the names of the series returned by Compute.
+	Outputs []string
+	// Validate checks constraints between resolved parameters, if set.
+	Validate func(params []float64) error
+	// Lookback returns the number of klines needed for one complete value.
+	Lookback func(params []float64) int
+	// Compute calculates the indicator with fully resolved parameters.
+	Compute func(klines []kline.Kline, params []float64) Output
+}
+
+// Registry maps indicator names to their definitions.
+type Registry struct {
+	definitions map[string]*Definition
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{definitions: make(map[string]*Definition)}
+}
+
+// Register adds a definition. Names must be unique.
+func (r *Registry) Register(def *Definition) error {
+	if def.Name == "" || def.Compute == nil || def.Lookback == nil {
+		return fmt.Errorf("indicator definition %q is incomplete", def.Name)
+	}
+	if _, exists := r.definitions[def.Name]; exists {
+		return fmt.Errorf("indicator %q is already registered", def.Name)
+	}
+	r.definitions[def.Name] = def
+	return nil
+}
+
+// Lookup returns the definition registered under name.
+func (r *Registry) Lookup(name string) (*Definition, bool) {
+	def, ok := r.definitions[name]
+	return def, ok
+}
+
+// Definitions returns all registered definitions sorted by name.
+func (r *Registry) Definitions() []*Definition {
+	defs := make([]*Definition, 0, len(r.definitions))
+	for _, def := range r.definitions {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(a, b int) bool { return defs[a].Name < defs[b].Name })
+	return defs
+}
+
+// Resolve validates a spec against its definition and fills in missing
+// trailing parameters with their defaults.
+func (r *Registry) Resolve(spec Spec) (*Definition, Spec, error) {
+	def, ok := r.Lookup(spec.Name)
+	if !ok {
+		return nil, Spec{}, fmt.Errorf("unknown indicator %q", spec.Name)
+	}
+	if len(spec.Params) > len(def.Params) {
+		return nil, Spec{}, fmt.Errorf("%s takes at most %d parameters, got %d", def.Name, len(def.Params), len(spec.Params))
+	}
+
+	resolved := Spec{Name: def.Name, Params: make([]float64, len(def.Params))}
+	for i, p := range def.Params {
+		v := p.Default
+		if i < len(spec.Params) {
+			v = spec.Params[i]
+		}
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, Spec{}, fmt.Errorf("%s: %s must be a finite number, got %g", def.Name, p.Name, v)
+		}
+		if v < p.Min {
+			return nil, Spec{}, fmt.Errorf("%s: %s must be at least %g, got %g", def.Name, p.Name, p.Min, v)
+		}
+		if p.Integer && v != float64(int(v)) {
+			return nil, Spec{}, fmt.Errorf("%s: %s must be a whole number, got %g", def.Name, p.Name, v)
+		}
+		resolved.Params[i] = v
+	}
+	if def.Validate != nil {
+		if err := def.Validate(resolved.Params); err != nil {
+			return nil, Spec{}, fmt.Errorf("%s: %w", def.Name, err)
+		}
+	}
+	return def, resolved, nil
+}
+
+// Evaluate resolves a spec and computes it over klines.
+func (r *Registry) Evaluate(spec Spec, klines []kline.Kline) (Spec, Output, error) {
+	def, resolved, err := r.Resolve(spec)
+	if err != nil {
+		return Spec{}, nil, err
+	}
+	if need := def.Lookback(resolved.Params); len(klines) < need {
+		return resolved, nil, fmt.Errorf("%s needs at least %d klines, got %d", resolved, need, len(klines))
+	}
+	return resolved, def.Compute(klines, resolved.Params), nil
+}
+
+// Latest returns the last value of every output series.
+func (o Output) Latest() map[string]float64 {
+	latest := make(map[string]float64, len(o))
+	for name, series := range o {
+		if len(series) > 0 {
+			latest[name] = series[len(series)-1]
+		}
+	}
+	return latest
+}
@@ -0,0 +1,87 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+	"tv-bot-go/pkg/kline"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    Spec
+		want    string
+		wantErr bool
+	}{
+		{name: "defaults", spec: Spec{Name: "macd"}, want: "macd(12,26,9)"},
+		{name: "trailing defaults", spec: Spec{Name: "bb", Params: []float64{30}}, want: "bb(30,2)"},
+		{name: "explicit", spec: Spec{Name: "ema", Params: []float64{200}}, want: "ema(200)"},
+		{name: "unknown", spec: Spec{Name: "foo"}, wantErr: true},
+		{name: "too many params", spec: Spec{Name: "ema", Params: []float64{1, 2}}, wantErr: true},
+		{name: "zero period", spec: Spec{Name: "ema", Params: []float64{0}}, wantErr: true},
+		{name: "negative period", spec: Spec{Name: "rsi", Params: []float64{-14}}, wantErr: true},
+		{name: "fractional period", spec: Spec{Name: "ema", Params: []float64{20.5}}, wantErr: true},
+		{name: "huge period", spec: Spec{Name: "ema", Params: []float64{1e300}}, wantErr: true},
+		{name: "nan", spec: Spec{Name: "bb", Params: []float64{20, math.NaN()}}, wantErr: true},
+		{name: "inf", spec: Spec{Name: "bb", Params: []float64{20, math.Inf(1)}}, wantErr: true},
+		{name: "macd fast equals slow", spec: Spec{Name: "macd", Params: []float64{26, 26, 9}}, wantErr: true},
+		{name: "macd fast above slow", spec: Spec{Name: "macd", Params: []float64{30, 26, 9}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, resolved, err := DefaultRegistry.Resolve(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && resolved.String() != tt.want {
+				t.Errorf("got %s, want %s", resolved, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterDuplicate(t *testing.T) {
+	r := NewRegistry()
+	def := &Definition{
+		Name:     "x",
+		Lookback: func([]float64) int { return 1 },
+		Compute:  func([]kline.Kline, []float64) Output { return nil },
+	}
+	if err := r.Register(def); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register(def); err == nil {
+		t.Error("registering a duplicate name succeeded")
+	}
+	if err := r.Register(&Definition{Name: "y"}); err == nil {
+		t.Error("registering an incomplete definition succeeded")
+	}
+}
+
+func TestEvaluateBuiltins(t *testing.T) {
+	klines := testKlines(300)
+	for _, def := range DefaultRegistry.Definitions() {
+		t.Run(def.Name, func(t *testing.T) {
+			spec, output, err := DefaultRegistry.Evaluate(Spec{Name: def.Name}, klines)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, name := range def.Outputs {
+				series, ok := output[name]
+				if !ok || len(series) == 0 {
+					t.Fatalf("%s: output %q is empty", spec, name)
+				}
+				if v := series[len(series)-1]; math.IsNaN(v) || math.IsInf(v, 0) {
+					t.Errorf("%s: latest %s = %v", spec, name, v)
+				}
+			}
+
+			// One kline short of the lookback must be rejected.
+			need := def.Lookback(spec.Params)
+			if _, _, err := DefaultRegistry.Evaluate(spec, klines[:need-1]); err == nil {
+				t.Errorf("%s: evaluating %d klines with a lookback of %d succeeded", spec, need-1, need)
+			}
+		})
+	}
+}
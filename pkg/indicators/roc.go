@@ -0,0 +1,18 @@
+package indicators
+
+// CalculateROC calculates the Rate of Change as a percentage over the given period.
+func CalculateROC(closes []float64, period int) []float64 {
+	if len(closes) <= period {
+		return nil
+	}
+
+	rocValues := make([]float64, len(closes)-period)
+	for i := period; i < len(closes); i++ {
+		if closes[i-period] == 0 {
+			continue
+		}
+		rocValues[i-period] = 100 * (closes[i] - closes[i-period]) / closes[i-period]
+	}
+
+	return rocValues
+}
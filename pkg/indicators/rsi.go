@@ -0,0 +1,36 @@
+package indicators
+
+// CalculateRSI calculates the Relative Strength Index using Wilder's smoothing.
+// The result is aligned so that the last value corresponds to the last close.
+func CalculateRSI(closes []float64, period int) []float64 {
+	if len(closes) <= period {
+		return nil
+	}
+
+	gains := make([]float64, len(closes)-1)
+	losses := make([]float64, len(closes)-1)
+	for i := 1; i < len(closes); i++ {
+		change := closes[i] - closes[i-1]
+		if change > 0 {
+			gains[i-1] = change
+		} else {
+			losses[i-1] = -change
+		}
+	}
+
+	// smooth returns Wilder sums rather than averages, but the ratio is the same.
+	smoothedGains := smooth(gains, period)
+	smoothedLosses := smooth(losses, period)
+
+	rsiValues := make([]float64, len(gains)-period+1)
+	for i := period - 1; i < len(gains); i++ {
+		if smoothedLosses[i] == 0 {
+			rsiValues[i-period+1] = 100
+			continue
+		}
+		rs := smoothedGains[i] / smoothedLosses[i]
+		rsiValues[i-period+1] = 100 - (100 / (1 + rs))
+	}
+
+	return rsiValues
+}
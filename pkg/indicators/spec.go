@@ -0,0 +1,92 @@
+package indicators
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Spec is a parsed indicator specification such as "macd(8,21,5)".
+type Spec struct {
+	Name   string
+	Params []float64
+}
+
+// ParseSpec parses a single indicator specification. The name is case-insensitive
+// and the parameter list is optional: "ema", "ema()" and "ema(200)" are all valid.
+func ParseSpec(s string) (Spec, error) {
+	s = strings.TrimSpace(s)
+	name, args, hasArgs := strings.Cut(s, "(")
+	spec := Spec{Name: strings.ToLower(strings.TrimSpace(name))}
+	if spec.Name == "" {
+		return Spec{}, fmt.Errorf("invalid indicator spec %q: missing name", s)
+	}
+
+	if !hasArgs {
+		return spec, nil
+	}
+	args, ok := strings.CutSuffix(strings.TrimSpace(args), ")")
+	if !ok {
+		return Spec{}, fmt.Errorf("invalid indicator spec %q: missing closing parenthesis", s)
+	}
+	if strings.TrimSpace(args) == "" {
+		return spec, nil
+	}
+
+	for _, arg := range strings.Split(args, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(arg), 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return Spec{}, fmt.Errorf("invalid indicator spec %q: bad parameter %q", s, strings.TrimSpace(arg))
+		}
+		spec.Params = append(spec.Params, v)
+	}
+	return spec, nil
+}
+
+// ParseSpecs parses a list of specifications separated by commas or whitespace
+// outside parentheses, e.g. "ema(200), macd(8,21,5) bb(20,2.5)".
+func ParseSpecs(s string) ([]Spec, error) {
+	var specs []Spec
+	depth, start := 0, 0
+	flush := func(end int) error {
+		if part := strings.TrimSpace(s[start:end]); part != "" {
+			spec, err := ParseSpec(part)
+			if err != nil {
+				return err
+			}
+			specs = append(specs, spec)
+		}
+		return nil
+	}
+
+	for i, r := range s {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case depth == 0 && (r == ',' || r == ' ' || r == ';'):
+			if err := flush(i); err != nil {
+				return nil, err
+			}
+			start = i + 1
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("invalid indicator list %q: unbalanced parentheses", s)
+	}
+	if err := flush(len(s)); err != nil {
+		return nil, err
+	}
+	return specs, nil
+}
+
+// String formats the spec in its canonical form, e.g. "bb(20,2.5)".
+func (s Spec) String() string {
+	params := make([]string, len(s.Params))
+	for i, p := range s.Params {
+		params[i] = strconv.FormatFloat(p, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%s(%s)", s.Name, strings.Join(params, ","))
+}
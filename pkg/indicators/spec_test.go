@@ -0,0 +1,60 @@
+package indicators
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSpecs(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []Spec
+		wantErr bool
+	}{
+		{in: "", want: nil},
+		{in: "ema", want: []Spec{{Name: "ema"}}},
+		{in: "EMA()", want: []Spec{{Name: "ema"}}},
+		{in: " ema( 200 ) ", want: []Spec{{Name: "ema", Params: []float64{200}}}},
+		{in: "ema(200), macd(8,21,5) bb(20,2.5);rsi", want: []Spec{
+			{Name: "ema", Params: []float64{200}},
+			{Name: "macd", Params: []float64{8, 21, 5}},
+			{Name: "bb", Params: []float64{20, 2.5}},
+			{Name: "rsi"},
+		}},
+		// Duplicates are kept; the analysis deduplicates resolved specs.
+		{in: "ema,ema", want: []Spec{{Name: "ema"}, {Name: "ema"}}},
+		{in: "bb(20,-1)", want: []Spec{{Name: "bb", Params: []float64{20, -1}}}},
+		{in: "(20)", wantErr: true},
+		{in: "ema(20", wantErr: true},
+		{in: "ema(20))", wantErr: true},
+		{in: "ema(abc)", wantErr: true},
+		{in: "ema(20,)", wantErr: true},
+		{in: "ema(NaN)", wantErr: true},
+		{in: "ema(Inf)", wantErr: true},
+		{in: "ema(-inf)", wantErr: true},
+		{in: "ema(1e400)", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseSpecs(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpecString(t *testing.T) {
+	spec := Spec{Name: "bb", Params: []float64{20, 2.5}}
+	if got := spec.String(); got != "bb(20,2.5)" {
+		t.Errorf("got %q", got)
+	}
+	parsed, err := ParseSpec(spec.String())
+	if err != nil || !reflect.DeepEqual(parsed, spec) {
+		t.Errorf("round trip = %+v, %v", parsed, err)
+	}
+}
@@ -0,0 +1,45 @@
+package indicators
+
+// SqueezeState describes the TTM-style squeeze on the latest bar.
+type SqueezeState struct {
+	// On is true when the Bollinger Bands sit inside the Keltner Channel.
+	On bool `json:"on"`
+	// Bars is the number of consecutive bars the current state has lasted.
+	Bars int `json:"bars"`
+	// Released is true when the squeeze turned off on the latest bar.
+	Released bool `json:"released"`
+}
+
+// CalculateSqueeze reports, for each bar, whether the Bollinger Bands are
+// completely inside the Keltner Channel. Both inputs are aligned to the end.
+func CalculateSqueeze(bands []BollingerBands, channels []KeltnerChannel) []bool {
+	length := min(len(bands), len(channels))
+	if length == 0 {
+		return nil
+	}
+
+	squeeze := make([]bool, length)
+	for i := 0; i < length; i++ {
+		bb := bands[len(bands)-length+i]
+		kc := channels[len(channels)-length+i]
+		squeeze[i] = bb.Upper < kc.Upper && bb.Lower > kc.Lower
+	}
+
+	return squeeze
+}
+
+// LatestSqueezeState summarizes the squeeze series into the state of its last bar.
+func LatestSqueezeState(squeeze []bool) *SqueezeState {
+	if len(squeeze) == 0 {
+		return nil
+	}
+
+	last := len(squeeze) - 1
+	state := &SqueezeState{On: squeeze[last]}
+	for i := last; i >= 0 && squeeze[i] == state.On; i-- {
+		state.Bars++
+	}
+	state.Released = !state.On && last > 0 && squeeze[last-1]
+
+	return state
+}
@@ -0,0 +1,79 @@
+package indicators
+
+// StochRSIIndicator holds the calculated values for the Stochastic RSI indicator.
+type StochRSIIndicator struct {
+	K float64 `json:"k"`
+	D float64 `json:"d"`
+}
+
+// CalculateStochRSI calculates the Stochastic RSI with %K and %D lines.
+// %K is the SMA of the raw stochastic of RSI, and %D is the SMA of %K.
+func CalculateStochRSI(closes []float64, rsiPeriod, stochPeriod, kPeriod, dPeriod int) []StochRSIIndicator {
+	rsi := CalculateRSI(closes, rsiPeriod)
+	if len(rsi) < stochPeriod {
+		return nil
+	}
+
+	stoch := make([]float64, len(rsi)-stochPeriod+1)
+	for i := stochPeriod - 1; i < len(rsi); i++ {
+		lowest, highest := minMax(rsi[i-stochPeriod+1 : i+1])
+		if highest-lowest == 0 {
+			// A flat RSI window has no range; treat it as the midpoint.
+			stoch[i-stochPeriod+1] = 50
+			continue
+		}
+		stoch[i-stochPeriod+1] = 100 * (rsi[i] - lowest) / (highest - lowest)
+	}
+
+	k := calculateSMA(stoch, kPeriod)
+	if k == nil {
+		return nil
+	}
+	d := calculateSMA(k[kPeriod-1:], dPeriod)
+	if d == nil {
+		return nil
+	}
+
+	results := make([]StochRSIIndicator, 0, len(d)-dPeriod+1)
+	for i := dPeriod - 1; i < len(d); i++ {
+		results = append(results, StochRSIIndicator{
+			K: k[i+kPeriod-1],
+			D: d[i],
+		})
+	}
+
+	return results
+}
+
+// calculateSMA calculates the Simple Moving Average.
+// Like calculateEMA, the first period-1 values are left as zero.
+func calculateSMA(data []float64, period int) []float64 {
+	if len(data) < period || period <= 0 {
+		return nil
+	}
+
+	sma := make([]float64, len(data))
+	for i := period - 1; i < len(data); i++ {
+		sum := 0.0
+		for j := i - period + 1; j <= i; j++ {
+			sum += data[j]
+		}
+		sma[i] = sum / float64(period)
+	}
+
+	return sma
+}
+
+// minMax returns the lowest and highest values in data.
+func minMax(data []float64) (float64, float64) {
+	lowest, highest := data[0], data[0]
+	for _, v := range data[1:] {
+		if v < lowest {
+			lowest = v
+		}
+		if v > highest {
+			highest = v
+		}
+	}
+	return lowest, highest
+}
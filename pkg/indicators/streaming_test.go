@@ -0,0 +1,184 @@
+package indicators
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"tv-bot-go/pkg/kline"
+)
+
+// testKlines returns a deterministic random walk of n hourly klines.
+func testKlines(n int) []kline.Kline {
+	r := rand.New(rand.NewSource(1))
+	klines := make([]kline.Kline, n)
+	price := 100.0
+	for i := range klines {
+		open := price
+		close := open + r.NormFloat64()
+		klines[i] = kline.Kline{
+			OpenTime:  int64(i) * 3600000,
+			CloseTime: int64(i+1)*3600000 - 1,
+			Open:      open,
+			High:      max(open, close) + r.Float64(),
+			Low:       min(open, close) - r.Float64(),
+			Close:     close,
+			Volume:    r.Float64() * 100,
+		}
+		price = close
+	}
+	return klines
+}
+
+// streamer adapts a streaming indicator for the parity test.
+type streamer struct {
+	update func(kline.Kline)
+	ready  func() bool
+	values func() []float64
+	// snapshot saves the state and returns a function restoring it.
+	snapshot func() (restore func())
+}
+
+func emaStreamer(period int) streamer {
+	e := NewEMA(period)
+	return streamer{
+		update:   func(k kline.Kline) { e.Update(k.Close) },
+		ready:    e.Ready,
+		values:   func() []float64 { return []float64{e.Value()} },
+		snapshot: func() func() { s := e.Snapshot(); return func() { e.Restore(s) } },
+	}
+}
+
+func macdStreamer(fast, slow, signal int) streamer {
+	m := NewMACD(fast, slow, signal)
+	return streamer{
+		update: m.Update,
+		ready:  m.Ready,
+		values: func() []float64 {
+			v := m.Value()
+			return []float64{v.MACD, v.Signal, v.Histogram}
+		},
+		snapshot: func() func() { s := m.Snapshot(); return func() { m.Restore(s) } },
+	}
+}
+
+func adxStreamer(period int) streamer {
+	a := NewADX(period)
+	return streamer{
+		update:   a.Update,
+		ready:    a.Ready,
+		values:   func() []float64 { return []float64{a.Value()} },
+		snapshot: func() func() { s := a.Snapshot(); return func() { a.Restore(s) } },
+	}
+}
+
+func obvStreamer() streamer {
+	o := NewOBV()
+	return streamer{
+		update:   o.Update,
+		ready:    o.Ready,
+		values:   func() []float64 { return []float64{o.Value()} },
+		snapshot: func() func() { s := o.Snapshot(); return func() { o.Restore(s) } },
+	}
+}
+
+func mfiStreamer(period int) streamer {
+	f := NewMFI(period)
+	return streamer{
+		update:   f.Update,
+		ready:    f.Ready,
+		values:   func() []float64 { return []float64{f.Value()} },
+		snapshot: func() func() { s := f.Snapshot(); return func() { f.Restore(s) } },
+	}
+}
+
+// latest returns the last value of each series, or nil if any series is empty.
+func latest(series ...[]float64) []float64 {
+	values := make([]float64, len(series))
+	for i, s := range series {
+		if len(s) == 0 {
+			return nil
+		}
+		values[i] = s[len(s)-1]
+	}
+	return values
+}
+
+func TestStreamingMatchesBatch(t *testing.T) {
+	klines := testKlines(200)
+	highs, lows, closes, volumes := kline.HLCV(klines)
+
+	tests := []struct {
+		name   string
+		stream func() streamer
+		// batch returns the latest batch values over the first n klines, or
+		// nil if the batch calculation has no value yet.
+		batch func(n int) []float64
+	}{
+		{"ema", func() streamer { return emaStreamer(20) }, func(n int) []float64 {
+			return latest(CalculateEMA(closes[:n], 20))
+		}},
+		{"macd", func() streamer { return macdStreamer(12, 26, 9) }, func(n int) []float64 {
+			result := CalculateMACD(closes[:n], 12, 26, 9)
+			if len(result) == 0 {
+				return nil
+			}
+			m := result[len(result)-1]
+			return []float64{m.MACD, m.Signal, m.Histogram}
+		}},
+		{"adx", func() streamer { return adxStreamer(14) }, func(n int) []float64 {
+			return latest(CalculateADX(highs[:n], lows[:n], closes[:n], 14))
+		}},
+		{"obv", obvStreamer, func(n int) []float64 {
+			return latest(CalculateOBV(closes[:n], volumes[:n]))
+		}},
+		{"mfi", func() streamer { return mfiStreamer(14) }, func(n int) []float64 {
+			return latest(CalculateMFI(highs[:n], lows[:n], closes[:n], volumes[:n], 14))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.stream()
+			for i, k := range klines {
+				// Halfway through, feed klines that are then rolled back.
+				if i == len(klines)/2 {
+					restore := s.snapshot()
+					for _, other := range testKlines(30) {
+						s.update(other)
+					}
+					restore()
+				}
+
+				s.update(k)
+				want := tt.batch(i + 1)
+				if s.ready() != (want != nil) {
+					t.Fatalf("kline %d: ready = %v, batch has value = %v", i, s.ready(), want != nil)
+				}
+				if want == nil {
+					continue
+				}
+				for j, got := range s.values() {
+					if math.Abs(got-want[j]) > 1e-9 {
+						t.Fatalf("kline %d: value %d = %v, batch = %v", i, j, got, want[j])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestStreamingClampsPeriod(t *testing.T) {
+	for _, s := range []streamer{emaStreamer(0), emaStreamer(-3), macdStreamer(0, 0, 0), adxStreamer(0), mfiStreamer(0)} {
+		for _, k := range testKlines(5) {
+			s.update(k)
+		}
+		if !s.ready() {
+			t.Errorf("not ready after 5 klines with a period below 1")
+		}
+		for _, v := range s.values() {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				t.Errorf("value %v is not finite", v)
+			}
+		}
+	}
+}
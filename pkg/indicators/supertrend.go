@@ -0,0 +1,45 @@
+package indicators
+
+// CalculateSupertrend calculates the Supertrend indicator using bands placed a
+// multiple of the ATR above and below the high/low midpoint.
+func CalculateSupertrend(highs, lows, closes []float64, period int, multiplier float64) *TrendStop {
+	atr := CalculateATR(highs, lows, closes, period)
+	if atr == nil {
+		return nil
+	}
+
+	result := newTrendStop(len(closes))
+	// The ATR is aligned to the end, so its first value belongs to kline `period`.
+	var finalUpper, finalLower float64
+	direction := 1
+	for i := period; i < len(closes); i++ {
+		midpoint := (highs[i] + lows[i]) / 2
+		basicUpper := midpoint + multiplier*atr[i-period]
+		basicLower := midpoint - multiplier*atr[i-period]
+
+		if i == period {
+			finalUpper, finalLower = basicUpper, basicLower
+		} else {
+			if basicUpper < finalUpper || closes[i-1] > finalUpper {
+				finalUpper = basicUpper
+			}
+			if basicLower > finalLower || closes[i-1] < finalLower {
+				finalLower = basicLower
+			}
+		}
+
+		if direction == 1 && closes[i] < finalLower {
+			direction = -1
+		} else if direction == -1 && closes[i] > finalUpper {
+			direction = 1
+		}
+
+		if direction == 1 {
+			result.set(i, finalLower, direction)
+		} else {
+			result.set(i, finalUpper, direction)
+		}
+	}
+
+	return result
+}
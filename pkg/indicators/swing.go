@@ -0,0 +1,34 @@
+package indicators
+
+// FindSwingHighs returns the indices of swing highs: values strictly higher than
+// the left values before them and at least as high as the right values after them.
+// A swing is only confirmed once right values have followed it.
+func FindSwingHighs(values []float64, left, right int) []int {
+	return findSwings(values, left, right, func(a, b float64) bool { return a > b })
+}
+
+// FindSwingLows returns the indices of swing lows: values strictly lower than
+// the left values before them and at most as low as the right values after them.
+func FindSwingLows(values []float64, left, right int) []int {
+	return findSwings(values, left, right, func(a, b float64) bool { return a < b })
+}
+
+// findSwings returns the indices whose value beats every neighbor within the
+// window according to better. Ties to the right are allowed so that flat tops
+// and bottoms produce a single swing at their first value.
+func findSwings(values []float64, left, right int, better func(a, b float64) bool) []int {
+	var swings []int
+	for i := left; i < len(values)-right; i++ {
+		isSwing := true
+		for j := i - left; j < i && isSwing; j++ {
+			isSwing = better(values[i], values[j])
+		}
+		for j := i + 1; j <= i+right && isSwing; j++ {
+			isSwing = !better(values[j], values[i])
+		}
+		if isSwing {
+			swings = append(swings, i)
+		}
+	}
+	return swings
+}
@@ -0,0 +1,43 @@
+package indicators
+
+// TrendFlip records a change of direction of a trailing-stop indicator.
+type TrendFlip struct {
+	// Index is the position of the kline on which the flip happened.
+	Index int `json:"index"`
+	// Direction is the new direction, 1 for up and -1 for down.
+	Direction int `json:"direction"`
+}
+
+// TrendStop holds the output of a trailing-stop style trend indicator such as
+// Supertrend or Parabolic SAR. Values and Directions have one entry per kline;
+// entries before the warm-up period are zero.
+type TrendStop struct {
+	Values     []float64
+	Directions []int
+	Flips      []TrendFlip
+}
+
+func newTrendStop(length int) *TrendStop {
+	return &TrendStop{
+		Values:     make([]float64, length),
+		Directions: make([]int, length),
+	}
+}
+
+// set stores the stop value and direction for a kline, recording a flip if the
+// direction changed from the previous kline.
+func (t *TrendStop) set(i int, value float64, direction int) {
+	t.Values[i] = value
+	t.Directions[i] = direction
+	if i > 0 && t.Directions[i-1] != 0 && t.Directions[i-1] != direction {
+		t.Flips = append(t.Flips, TrendFlip{Index: i, Direction: direction})
+	}
+}
+
+// LastFlip returns the most recent flip, or nil if the direction never changed.
+func (t *TrendStop) LastFlip() *TrendFlip {
+	if t == nil || len(t.Flips) == 0 {
+		return nil
+	}
+	return &t.Flips[len(t.Flips)-1]
+}
@@ -0,0 +1,140 @@
+package indicators
+
+// moneyFlowMultiplier places the close within the high-low range, from -1 at
+// the low to 1 at the high. It is 0 for a candle without range.
+func moneyFlowMultiplier(high, low, close float64) float64 {
+	if high == low {
+		return 0
+	}
+	return ((close - low) - (high - close)) / (high - low)
+}
+
+// CalculateAD calculates the Accumulation/Distribution line, the running sum
+// of volume weighted by where each candle closes within its range.
+func CalculateAD(highs, lows, closes, volumes []float64) []float64 {
+	if len(closes) == 0 || len(highs) != len(closes) || len(lows) != len(closes) || len(volumes) != len(closes) {
+		return nil
+	}
+
+	ad := make([]float64, len(closes))
+	sum := 0.0
+	for i := range closes {
+		sum += moneyFlowMultiplier(highs[i], lows[i], closes[i]) * volumes[i]
+		ad[i] = sum
+	}
+	return ad
+}
+
+// CalculateCMF calculates the Chaikin Money Flow: the money flow volume over
+// the period divided by the total volume over the period, between -1 and 1.
+func CalculateCMF(highs, lows, closes, volumes []float64, period int) []float64 {
+	if period < 1 || len(closes) < period || len(highs) != len(closes) || len(lows) != len(closes) || len(volumes) != len(closes) {
+		return nil
+	}
+
+	cmf := make([]float64, len(closes)-period+1)
+	for i := range cmf {
+		var flow, volume float64
+		for j := i; j < i+period; j++ {
+			flow += moneyFlowMultiplier(highs[j], lows[j], closes[j]) * volumes[j]
+			volume += volumes[j]
+		}
+		if volume != 0 {
+			cmf[i] = flow / volume
+		}
+	}
+	return cmf
+}
+
+// CalculateChaikinOscillator calculates the difference between the fast and
+// slow EMAs of the Accumulation/Distribution line (traditionally 3 and 10).
+func CalculateChaikinOscillator(highs, lows, closes, volumes []float64, fastPeriod, slowPeriod int) []float64 {
+	ad := CalculateAD(highs, lows, closes, volumes)
+	fast := CalculateEMA(ad, fastPeriod)
+	slow := CalculateEMA(ad, slowPeriod)
+	if len(fast) == 0 || len(slow) == 0 {
+		return nil
+	}
+
+	n := min(len(fast), len(slow))
+	fast, slow = fast[len(fast)-n:], slow[len(slow)-n:]
+	oscillator := make([]float64, n)
+	for i := range oscillator {
+		oscillator[i] = fast[i] - slow[i]
+	}
+	return oscillator
+}
+
+// CalculateForceIndex calculates Elder's Force Index: the EMA of the price
+// change multiplied by volume.
+func CalculateForceIndex(closes, volumes []float64, period int) []float64 {
+	if len(closes) < 2 || len(volumes) != len(closes) {
+		return nil
+	}
+
+	raw := make([]float64, len(closes)-1)
+	for i := 1; i < len(closes); i++ {
+		raw[i-1] = (closes[i] - closes[i-1]) * volumes[i]
+	}
+	return CalculateEMA(raw, period)
+}
+
+// CalculateEaseOfMovement calculates the SMA of the Ease of Movement: the
+// move of the candle midpoint relative to the volume needed for it, per unit
+// of range. scale multiplies the result to a readable magnitude.
+func CalculateEaseOfMovement(highs, lows, volumes []float64, period int, scale float64) []float64 {
+	if len(highs) < 2 || len(lows) != len(highs) || len(volumes) != len(highs) {
+		return nil
+	}
+
+	raw := make([]float64, len(highs)-1)
+	for i := 1; i < len(highs); i++ {
+		if volumes[i] == 0 {
+			continue
+		}
+		distance := (highs[i]+lows[i])/2 - (highs[i-1]+lows[i-1])/2
+		raw[i-1] = scale * distance * (highs[i] - lows[i]) / volumes[i]
+	}
+	return CalculateSMA(raw, period)
+}
+
+// CalculateRVOL calculates relative volume: each volume divided by the
+// average volume of the preceding period candles.
+func CalculateRVOL(volumes []float64, period int) []float64 {
+	if period < 1 || len(volumes) <= period {
+		return nil
+	}
+
+	rvol := make([]float64, len(volumes)-period)
+	sum := 0.0
+	for i := 0; i < period; i++ {
+		sum += volumes[i]
+	}
+	for i := period; i < len(volumes); i++ {
+		if sum != 0 {
+			rvol[i-period] = volumes[i] / (sum / float64(period))
+		}
+		sum += volumes[i] - volumes[i-period]
+	}
+	return rvol
+}
+
+// CalculateVolumeOscillator calculates the percentage difference between the
+// fast and slow EMAs of volume.
+func CalculateVolumeOscillator(volumes []float64, fastPeriod, slowPeriod int) []float64 {
+	fast := CalculateEMA(volumes, fastPeriod)
+	slow := CalculateEMA(volumes, slowPeriod)
+	if len(fast) == 0 || len(slow) == 0 {
+		return nil
+	}
+
+	n := min(len(fast), len(slow))
+	fast, slow = fast[len(fast)-n:], slow[len(slow)-n:]
+	oscillator := make([]float64, n)
+	for i := range oscillator {
+		if slow[i] != 0 {
+			oscillator[i] = 100 * (fast[i] - slow[i]) / slow[i]
+		}
+	}
+	return oscillator
+}
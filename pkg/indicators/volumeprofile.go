@@ -0,0 +1,120 @@
+package indicators
+
+import "tv-bot-go/pkg/kline"
+
+// VolumeBin is a single price bin of a volume profile.
+type VolumeBin struct {
+	Low    float64 `json:"low"`
+	High   float64 `json:"high"`
+	Volume float64 `json:"volume"`
+}
+
+// VolumeProfile is the distribution of traded volume across price over a window.
+type VolumeProfile struct {
+	Low  float64 `json:"low"`
+	High float64 `json:"high"`
+	// Bins are ordered from the lowest price up. They are left out of the JSON
+	// encoding to keep prompts compact; charting code reads them directly.
+	Bins []VolumeBin `json:"-"`
+	// POC is the midpoint of the bin with the most volume (point of control).
+	POC float64 `json:"poc"`
+	// ValueAreaHigh and ValueAreaLow bound the bins holding the value area volume around the POC.
+	ValueAreaHigh float64 `json:"value_area_high"`
+	ValueAreaLow  float64 `json:"value_area_low"`
+	// HighVolumeNodes and LowVolumeNodes are the midpoints of bins that are
+	// local volume peaks above the average, and troughs below it.
+	HighVolumeNodes []float64 `json:"high_volume_nodes"`
+	LowVolumeNodes  []float64 `json:"low_volume_nodes"`
+}
+
+// CalculateVolumeProfile bins the volume of klines by price. Each kline's volume
+// is spread evenly over its high-low range. valueArea is the fraction of total
+// volume in the value area, traditionally 0.7.
+func CalculateVolumeProfile(klines []kline.Kline, binCount int, valueArea float64) *VolumeProfile {
+	if len(klines) == 0 || binCount <= 0 {
+		return nil
+	}
+
+	low, high := klines[0].Low, klines[0].High
+	for _, k := range klines[1:] {
+		low = min(low, k.Low)
+		high = max(high, k.High)
+	}
+	if high <= low {
+		return nil
+	}
+
+	binSize := (high - low) / float64(binCount)
+	profile := &VolumeProfile{Low: low, High: high, Bins: make([]VolumeBin, binCount)}
+	for i := range profile.Bins {
+		profile.Bins[i].Low = low + float64(i)*binSize
+		profile.Bins[i].High = low + float64(i+1)*binSize
+	}
+
+	totalVolume := 0.0
+	for _, k := range klines {
+		totalVolume += k.Volume
+		if k.High == k.Low {
+			profile.Bins[binIndex(k.Close, low, binSize, binCount)].Volume += k.Volume
+			continue
+		}
+		first := binIndex(k.Low, low, binSize, binCount)
+		last := binIndex(k.High, low, binSize, binCount)
+		for i := first; i <= last; i++ {
+			bin := &profile.Bins[i]
+			overlap := min(bin.High, k.High) - max(bin.Low, k.Low)
+			bin.Volume += k.Volume * overlap / (k.High - k.Low)
+		}
+	}
+
+	poc := 0
+	for i, bin := range profile.Bins {
+		if bin.Volume > profile.Bins[poc].Volume {
+			poc = i
+		}
+	}
+	profile.POC = (profile.Bins[poc].Low + profile.Bins[poc].High) / 2
+
+	// Expand from the POC towards the heavier neighboring bin until the value
+	// area holds the requested share of the volume.
+	lo, hi := poc, poc
+	areaVolume := profile.Bins[poc].Volume
+	for areaVolume < valueArea*totalVolume && (lo > 0 || hi < binCount-1) {
+		below, above := -1.0, -1.0
+		if lo > 0 {
+			below = profile.Bins[lo-1].Volume
+		}
+		if hi < binCount-1 {
+			above = profile.Bins[hi+1].Volume
+		}
+		if above >= below {
+			hi++
+			areaVolume += above
+		} else {
+			lo--
+			areaVolume += below
+		}
+	}
+	profile.ValueAreaLow = profile.Bins[lo].Low
+	profile.ValueAreaHigh = profile.Bins[hi].High
+
+	average := totalVolume / float64(binCount)
+	for i := 1; i < binCount-1; i++ {
+		v, prev, next := profile.Bins[i].Volume, profile.Bins[i-1].Volume, profile.Bins[i+1].Volume
+		mid := (profile.Bins[i].Low + profile.Bins[i].High) / 2
+		if v > prev && v >= next && v > average {
+			profile.HighVolumeNodes = append(profile.HighVolumeNodes, mid)
+		}
+		if v < prev && v <= next && v < average {
+			profile.LowVolumeNodes = append(profile.LowVolumeNodes, mid)
+		}
+	}
+
+	return profile
+}
+
+// binIndex returns the bin containing price, clamped to the valid range.
+func binIndex(price, low, binSize float64, binCount int) int {
+	i := int((price - low) / binSize)
+	return min(max(i, 0), binCount-1)
+}
@@ -0,0 +1,129 @@
+package indicators
+
+import (
+	"math"
+	"time"
+	"tv-bot-go/pkg/kline"
+)
+
+// VWAPSession selects the period after which a session VWAP resets.
+type VWAPSession int
+
+const (
+	// DailySession resets at 00:00 UTC every day.
+	DailySession VWAPSession = iota
+	// WeeklySession resets at 00:00 UTC every Monday.
+	WeeklySession
+)
+
+// VWAPBands holds a VWAP value with its volume-weighted standard deviation bands.
+type VWAPBands struct {
+	VWAP   float64 `json:"vwap"`
+	StdDev float64 `json:"std_dev"`
+	Upper1 float64 `json:"upper_1"`
+	Lower1 float64 `json:"lower_1"`
+	Upper2 float64 `json:"upper_2"`
+	Lower2 float64 `json:"lower_2"`
+}
+
+// vwapAccumulator keeps the running sums needed for VWAP and its deviation.
+type vwapAccumulator struct {
+	volume, quoteVolume, weightedSquares float64
+}
+
+func (a *vwapAccumulator) add(k kline.Kline) VWAPBands {
+	// The quote volume divided by the base volume is the candle's true average
+	// trade price; fall back to the typical price for empty candles.
+	price := (k.High + k.Low + k.Close) / 3
+	quoteVolume := k.QuoteAssetVolume
+	if k.Volume > 0 && quoteVolume > 0 {
+		price = quoteVolume / k.Volume
+	} else {
+		quoteVolume = price * k.Volume
+	}
+
+	a.volume += k.Volume
+	a.quoteVolume += quoteVolume
+	a.weightedSquares += k.Volume * price * price
+
+	if a.volume == 0 {
+		return VWAPBands{VWAP: price, Upper1: price, Lower1: price, Upper2: price, Lower2: price}
+	}
+
+	vwap := a.quoteVolume / a.volume
+	stdDev := math.Sqrt(math.Max(a.weightedSquares/a.volume-vwap*vwap, 0))
+	return VWAPBands{
+		VWAP:   vwap,
+		StdDev: stdDev,
+		Upper1: vwap + stdDev,
+		Lower1: vwap - stdDev,
+		Upper2: vwap + 2*stdDev,
+		Lower2: vwap - 2*stdDev,
+	}
+}
+
+// CalculateSessionVWAP calculates a VWAP that resets at the start of every
+// session. The result has one value per kline. Note that the first session is
+// only as complete as the klines supplied; compare klines[0].OpenTime with
+// SessionStart to tell whether it is.
+func CalculateSessionVWAP(klines []kline.Kline, session VWAPSession) []VWAPBands {
+	if len(klines) == 0 {
+		return nil
+	}
+
+	results := make([]VWAPBands, len(klines))
+	var acc vwapAccumulator
+	currentSession := SessionStart(klines[0].OpenTime, session)
+	for i, k := range klines {
+		if start := SessionStart(k.OpenTime, session); start != currentSession {
+			acc = vwapAccumulator{}
+			currentSession = start
+		}
+		results[i] = acc.add(k)
+	}
+
+	return results
+}
+
+// CalculateAnchoredVWAP calculates a VWAP starting from the kline that
+// contains anchor (a Unix timestamp in milliseconds), or the next kline if the
+// anchor falls in a gap between klines.
+// The result is aligned so that the last value corresponds to the last kline.
+// It returns nil if the anchor is before the first kline, since the volume
+// traded between the anchor and that kline is unknown, or after the last one.
+func CalculateAnchoredVWAP(klines []kline.Kline, anchor int64) []VWAPBands {
+	if len(klines) == 0 || anchor < klines[0].OpenTime {
+		return nil
+	}
+
+	start := -1
+	for i, k := range klines {
+		if k.CloseTime >= anchor {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return nil
+	}
+
+	results := make([]VWAPBands, 0, len(klines)-start)
+	var acc vwapAccumulator
+	for _, k := range klines[start:] {
+		results = append(results, acc.add(k))
+	}
+
+	return results
+}
+
+// SessionStart returns the start of the session containing openTime, in milliseconds.
+func SessionStart(openTime int64, session VWAPSession) int64 {
+	t := time.UnixMilli(openTime).UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if session == WeeklySession {
+		// time.Weekday starts on Sunday; shift so that weeks start on Monday.
+		offset := (int(day.Weekday()) + 6) % 7
+		day = day.AddDate(0, 0, -offset)
+	}
+	return day.UnixMilli()
+}
@@ -0,0 +1,44 @@
+package indicators
+
+import "testing"
+
+func TestCalculateAnchoredVWAPStart(t *testing.T) {
+	klines := testKlines(10)
+	hour := int64(3600000)
+
+	tests := []struct {
+		name   string
+		anchor int64
+		want   int // number of values, or 0 for nil
+	}{
+		{"first open", 0, 10},
+		{"inside a candle", 3*hour + hour/2, 7},
+		{"candle open", 3 * hour, 7},
+		{"last candle", 9*hour + 1, 1},
+		{"before first", -1, 0},
+		{"after last", 10 * hour, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalculateAnchoredVWAP(klines, tt.anchor); len(got) != tt.want {
+				t.Errorf("got %d values, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateSessionVWAPResets(t *testing.T) {
+	// 30 hourly klines starting at the Unix epoch cross one daily boundary.
+	klines := testKlines(30)
+	result := CalculateSessionVWAP(klines, DailySession)
+	if len(result) != len(klines) {
+		t.Fatalf("got %d values, want %d", len(result), len(klines))
+	}
+	if want := CalculateAnchoredVWAP(klines, 24*3600000); result[29] != want[len(want)-1] {
+		t.Errorf("session VWAP after the reset = %+v, want %+v", result[29], want[len(want)-1])
+	}
+	if SessionStart(klines[29].OpenTime, DailySession) != 24*3600000 {
+		t.Errorf("daily session start = %d", SessionStart(klines[29].OpenTime, DailySession))
+	}
+}
@@ -0,0 +1,22 @@
+package indicators
+
+// CalculateWilliamsR calculates Williams %R, ranging from -100 (oversold) to 0 (overbought).
+// It requires high, low, and close prices, and a period for the calculation.
+func CalculateWilliamsR(highs, lows, closes []float64, period int) []float64 {
+	if len(closes) < period || len(highs) != len(closes) || len(lows) != len(closes) {
+		return nil
+	}
+
+	wrValues := make([]float64, len(closes)-period+1)
+	for i := period - 1; i < len(closes); i++ {
+		_, highest := minMax(highs[i-period+1 : i+1])
+		lowest, _ := minMax(lows[i-period+1 : i+1])
+		if highest-lowest == 0 {
+			wrValues[i-period+1] = -50
+			continue
+		}
+		wrValues[i-period+1] = -100 * (highest - closes[i]) / (highest - lowest)
+	}
+
+	return wrValues
+}
@@ -0,0 +1,35 @@
+// Package kline defines the candlestick type shared by the analysis packages.
+package kline
+
+// Kline represents a single kline/candlestick.
+type Kline struct {
+	OpenTime                 int64
+	Open, High, Low, Close   float64
+	Volume                   float64
+	CloseTime                int64
+	QuoteAssetVolume         float64
+	NumberOfTrades           int64
+	TakerBuyBaseAssetVolume  float64
+	TakerBuyQuoteAssetVolume float64
+}
+
+// Closes returns the closing prices of klines.
+func Closes(klines []Kline) []float64 {
+	closes := make([]float64, len(klines))
+	for i, k := range klines {
+		closes[i] = k.Close
+	}
+	return closes
+}
+
+// HLCV returns the high, low, close and volume series of klines.
+func HLCV(klines []Kline) (highs, lows, closes, volumes []float64) {
+	highs = make([]float64, len(klines))
+	lows = make([]float64, len(klines))
+	closes = make([]float64, len(klines))
+	volumes = make([]float64, len(klines))
+	for i, k := range klines {
+		highs[i], lows[i], closes[i], volumes[i] = k.High, k.Low, k.Close, k.Volume
+	}
+	return
+}
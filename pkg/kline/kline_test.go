@@ -0,0 +1,24 @@
+package kline
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSeries(t *testing.T) {
+	klines := []Kline{
+		{Open: 1, High: 3, Low: 0.5, Close: 2, Volume: 10},
+		{Open: 2, High: 4, Low: 1.5, Close: 3, Volume: 20},
+	}
+	if got := Closes(klines); !reflect.DeepEqual(got, []float64{2, 3}) {
+		t.Errorf("Closes = %v", got)
+	}
+	highs, lows, closes, volumes := HLCV(klines)
+	want := [][]float64{{3, 4}, {0.5, 1.5}, {2, 3}, {10, 20}}
+	if got := [][]float64{highs, lows, closes, volumes}; !reflect.DeepEqual(got, want) {
+		t.Errorf("HLCV = %v, want %v", got, want)
+	}
+	if got := Closes(nil); len(got) != 0 {
+		t.Errorf("Closes(nil) = %v", got)
+	}
+}
@@ -0,0 +1,37 @@
+package levels
+
+import "sort"
+
+// Level is a named price level.
+type Level struct {
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+	// DistancePercent is the distance from the reference price, set by Nearest.
+	DistancePercent float64 `json:"distance_percent"`
+}
+
+// Nearest returns up to n levels above and n levels below price, each ordered
+// from the closest to the furthest.
+func Nearest(levels []Level, price float64, n int) (above, below []Level) {
+	for _, l := range levels {
+		if price != 0 {
+			l.DistancePercent = 100 * (l.Price - price) / price
+		}
+		if l.Price > price {
+			above = append(above, l)
+		} else {
+			below = append(below, l)
+		}
+	}
+
+	sort.SliceStable(above, func(a, b int) bool { return above[a].Price < above[b].Price })
+	sort.SliceStable(below, func(a, b int) bool { return below[a].Price > below[b].Price })
+
+	if len(above) > n {
+		above = above[:n]
+	}
+	if len(below) > n {
+		below = below[:n]
+	}
+	return above, below
+}
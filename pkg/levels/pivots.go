@@ -0,0 +1,70 @@
+// Package levels computes support and resistance levels from kline data.
+package levels
+
+import (
+	"fmt"
+	"tv-bot-go/pkg/kline"
+)
+
+// PivotMethod selects the formula used to calculate pivot points.
+type PivotMethod string
+
+const (
+	Classic   PivotMethod = "classic"
+	Fibonacci PivotMethod = "fibonacci"
+	Camarilla PivotMethod = "camarilla"
+	Woodie    PivotMethod = "woodie"
+)
+
+// PivotMethods lists every supported pivot method.
+var PivotMethods = []PivotMethod{Classic, Fibonacci, Camarilla, Woodie}
+
+// PivotPoints holds the pivot and its resistance and support levels, ordered
+// from the closest to the furthest from the pivot (R1, R2, ... and S1, S2, ...).
+type PivotPoints struct {
+	Method      PivotMethod `json:"method"`
+	Pivot       float64     `json:"pivot"`
+	Resistances []float64   `json:"resistances"`
+	Supports    []float64   `json:"supports"`
+}
+
+// CalculatePivotPoints calculates pivot points from a completed candle,
+// normally the previous day or week.
+func CalculatePivotPoints(prev kline.Kline, method PivotMethod) PivotPoints {
+	h, l, c := prev.High, prev.Low, prev.Close
+	r := h - l
+	p := PivotPoints{Method: method, Pivot: (h + l + c) / 3}
+
+	switch method {
+	case Fibonacci:
+		p.Resistances = []float64{p.Pivot + 0.382*r, p.Pivot + 0.618*r, p.Pivot + r}
+		p.Supports = []float64{p.Pivot - 0.382*r, p.Pivot - 0.618*r, p.Pivot - r}
+	case Camarilla:
+		p.Resistances = []float64{c + r*1.1/12, c + r*1.1/6, c + r*1.1/4, c + r*1.1/2}
+		p.Supports = []float64{c - r*1.1/12, c - r*1.1/6, c - r*1.1/4, c - r*1.1/2}
+	case Woodie:
+		// This variant weights the previous close, so it needs no current open.
+		p.Pivot = (h + l + 2*c) / 4
+		p.Resistances = []float64{2*p.Pivot - l, p.Pivot + r}
+		p.Supports = []float64{2*p.Pivot - h, p.Pivot - r}
+	default:
+		p.Method = Classic
+		p.Resistances = []float64{2*p.Pivot - l, p.Pivot + r, h + 2*(p.Pivot-l)}
+		p.Supports = []float64{2*p.Pivot - h, p.Pivot - r, l - 2*(h-p.Pivot)}
+	}
+
+	return p
+}
+
+// Levels flattens the pivot points into named levels prefixed with period,
+// e.g. "daily classic R1".
+func (p PivotPoints) Levels(period string) []Level {
+	levels := []Level{{Name: fmt.Sprintf("%s %s P", period, p.Method), Price: p.Pivot}}
+	for i, price := range p.Resistances {
+		levels = append(levels, Level{Name: fmt.Sprintf("%s %s R%d", period, p.Method, i+1), Price: price})
+	}
+	for i, price := range p.Supports {
+		levels = append(levels, Level{Name: fmt.Sprintf("%s %s S%d", period, p.Method, i+1), Price: price})
+	}
+	return levels
+}
@@ -0,0 +1,90 @@
+package levels
+
+import (
+	"fmt"
+	"sort"
+	"tv-bot-go/pkg/indicators"
+	"tv-bot-go/pkg/kline"
+)
+
+// Zone is a price area where several swing highs or lows have clustered.
+type Zone struct {
+	Low  float64 `json:"low"`
+	High float64 `json:"high"`
+	Mid  float64 `json:"mid"`
+	// Touches is the number of swings in the zone.
+	Touches int `json:"touches"`
+	// LastIndex is the kline index of the most recent swing in the zone.
+	LastIndex int `json:"last_index"`
+	// Score ranks zones by touches, weighted towards recent swings.
+	Score float64 `json:"score"`
+}
+
+type swing struct {
+	price float64
+	index int
+}
+
+// ClusterZones groups the swing highs and lows of klines into zones. Swings
+// whose prices are within tolerance of a zone's running mean join that zone.
+// Zones are returned ranked by score, highest first.
+func ClusterZones(klines []kline.Kline, left, right int, tolerance float64) []Zone {
+	if len(klines) == 0 {
+		return nil
+	}
+
+	highs := make([]float64, len(klines))
+	lows := make([]float64, len(klines))
+	for i, k := range klines {
+		highs[i], lows[i] = k.High, k.Low
+	}
+
+	var swings []swing
+	for _, i := range indicators.FindSwingHighs(highs, left, right) {
+		swings = append(swings, swing{price: highs[i], index: i})
+	}
+	for _, i := range indicators.FindSwingLows(lows, left, right) {
+		swings = append(swings, swing{price: lows[i], index: i})
+	}
+	sort.Slice(swings, func(a, b int) bool { return swings[a].price < swings[b].price })
+
+	var zones []Zone
+	sum := 0.0
+	for _, s := range swings {
+		if n := len(zones); n > 0 && s.price-zones[n-1].Mid <= tolerance {
+			z := &zones[n-1]
+			sum += s.price
+			z.Touches++
+			z.High = s.price
+			z.Mid = sum / float64(z.Touches)
+			z.LastIndex = max(z.LastIndex, s.index)
+			z.Score += recencyWeight(s.index, len(klines))
+			continue
+		}
+		sum = s.price
+		zones = append(zones, Zone{
+			Low:       s.price,
+			High:      s.price,
+			Mid:       s.price,
+			Touches:   1,
+			LastIndex: s.index,
+			Score:     recencyWeight(s.index, len(klines)),
+		})
+	}
+
+	sort.SliceStable(zones, func(a, b int) bool { return zones[a].Score > zones[b].Score })
+	return zones
+}
+
+// recencyWeight scores a swing between 0.5 for the oldest kline and 1 for the newest.
+func recencyWeight(index, length int) float64 {
+	if length <= 1 {
+		return 1
+	}
+	return 0.5 + 0.5*float64(index)/float64(length-1)
+}
+
+// Level returns the zone as a named level at its mid price.
+func (z Zone) Level() Level {
+	return Level{Name: fmt.Sprintf("S/R zone (%d touches)", z.Touches), Price: z.Mid}
+}
@@ -0,0 +1,60 @@
+package patterns
+
+import (
+	"math"
+	"tv-bot-go/pkg/kline"
+)
+
+func body(k kline.Kline) float64 {
+	return math.Abs(k.Close - k.Open)
+}
+
+func candleRange(k kline.Kline) float64 {
+	return k.High - k.Low
+}
+
+func upperShadow(k kline.Kline) float64 {
+	return k.High - math.Max(k.Open, k.Close)
+}
+
+func lowerShadow(k kline.Kline) float64 {
+	return math.Min(k.Open, k.Close) - k.Low
+}
+
+func isBullish(k kline.Kline) bool {
+	return k.Close > k.Open
+}
+
+func isBearish(k kline.Kline) bool {
+	return k.Close < k.Open
+}
+
+func bodyTop(k kline.Kline) float64 {
+	return math.Max(k.Open, k.Close)
+}
+
+func bodyBottom(k kline.Kline) float64 {
+	return math.Min(k.Open, k.Close)
+}
+
+func midpoint(k kline.Kline) float64 {
+	return (k.Open + k.Close) / 2
+}
+
+// bodyRatio returns the body as a fraction of the full candle range.
+func bodyRatio(k kline.Kline) float64 {
+	if candleRange(k) == 0 {
+		return 0
+	}
+	return body(k) / candleRange(k)
+}
+
+// isLong reports whether the candle body is large relative to the series' average range.
+func (c *context) isLong(k kline.Kline) bool {
+	return body(k) >= 0.6*c.averageRange
+}
+
+// isSmall reports whether the candle body is small relative to the series' average range.
+func (c *context) isSmall(k kline.Kline) bool {
+	return body(k) <= 0.3*c.averageRange
+}
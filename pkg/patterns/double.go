@@ -0,0 +1,103 @@
+package patterns
+
+import (
+	"math"
+	"tv-bot-go/pkg/kline"
+)
+
+// engulfing is a candle whose body fully covers the opposite-colored body before it.
+func engulfing(klines []kline.Kline, i int, ctx *context) (Pattern, bool) {
+	if i < 1 {
+		return Pattern{}, false
+	}
+	prev, curr := klines[i-1], klines[i]
+	if body(prev) == 0 || body(curr) <= body(prev) ||
+		bodyTop(curr) < bodyTop(prev) || bodyBottom(curr) > bodyBottom(prev) {
+		return Pattern{}, false
+	}
+
+	shape := 1 - body(prev)/body(curr)
+	switch {
+	case isBearish(prev) && isBullish(curr):
+		return Pattern{Name: "bullish_engulfing", Bias: Bullish, Candles: 2, Strength: trendScore(shape, ctx.trendBefore(i-1), -1)}, true
+	case isBullish(prev) && isBearish(curr):
+		return Pattern{Name: "bearish_engulfing", Bias: Bearish, Candles: 2, Strength: trendScore(shape, ctx.trendBefore(i-1), 1)}, true
+	}
+	return Pattern{}, false
+}
+
+// harami is a small body, at most half the size of the long opposite-colored
+// body before it, contained within that body.
+func harami(klines []kline.Kline, i int, ctx *context) (Pattern, bool) {
+	if i < 1 {
+		return Pattern{}, false
+	}
+	prev, curr := klines[i-1], klines[i]
+	if !ctx.isLong(prev) || body(curr) == 0 || body(curr) > 0.5*body(prev) ||
+		bodyTop(curr) > bodyTop(prev) || bodyBottom(curr) < bodyBottom(prev) {
+		return Pattern{}, false
+	}
+
+	shape := 1 - 2*body(curr)/body(prev)
+	switch {
+	case isBearish(prev) && isBullish(curr):
+		return Pattern{Name: "bullish_harami", Bias: Bullish, Candles: 2, Strength: trendScore(shape, ctx.trendBefore(i-1), -1)}, true
+	case isBullish(prev) && isBearish(curr):
+		return Pattern{Name: "bearish_harami", Bias: Bearish, Candles: 2, Strength: trendScore(shape, ctx.trendBefore(i-1), 1)}, true
+	}
+	return Pattern{}, false
+}
+
+// piercingLine is a bullish candle that opens at or below a long bearish candle's
+// close and closes above its midpoint.
+func piercingLine(klines []kline.Kline, i int, ctx *context) (Pattern, bool) {
+	if i < 1 {
+		return Pattern{}, false
+	}
+	prev, curr := klines[i-1], klines[i]
+	if !isBearish(prev) || !ctx.isLong(prev) || !isBullish(curr) ||
+		curr.Open > prev.Close || curr.Close <= midpoint(prev) || curr.Close >= prev.Open {
+		return Pattern{}, false
+	}
+
+	shape := (curr.Close - midpoint(prev)) / (prev.Open - midpoint(prev))
+	return Pattern{Name: "piercing_line", Bias: Bullish, Candles: 2, Strength: trendScore(shape, ctx.trendBefore(i-1), -1)}, true
+}
+
+// darkCloudCover is a bearish candle that opens at or above a long bullish candle's
+// close and closes below its midpoint.
+func darkCloudCover(klines []kline.Kline, i int, ctx *context) (Pattern, bool) {
+	if i < 1 {
+		return Pattern{}, false
+	}
+	prev, curr := klines[i-1], klines[i]
+	if !isBullish(prev) || !ctx.isLong(prev) || !isBearish(curr) ||
+		curr.Open < prev.Close || curr.Close >= midpoint(prev) || curr.Close <= prev.Open {
+		return Pattern{}, false
+	}
+
+	shape := (midpoint(prev) - curr.Close) / (midpoint(prev) - prev.Open)
+	return Pattern{Name: "dark_cloud_cover", Bias: Bearish, Candles: 2, Strength: trendScore(shape, ctx.trendBefore(i-1), 1)}, true
+}
+
+// tweezers are two opposite-colored candles with matching lows after a decline
+// (tweezer bottom) or matching highs after an advance (tweezer top).
+func tweezers(klines []kline.Kline, i int, ctx *context) (Pattern, bool) {
+	if i < 1 {
+		return Pattern{}, false
+	}
+	prev, curr := klines[i-1], klines[i]
+	tolerance := 0.05 * ctx.averageRange
+	if tolerance == 0 {
+		return Pattern{}, false
+	}
+
+	trend := ctx.trendBefore(i - 1)
+	if diff := math.Abs(prev.Low - curr.Low); trend == -1 && isBearish(prev) && isBullish(curr) && diff <= tolerance {
+		return Pattern{Name: "tweezer_bottom", Bias: Bullish, Candles: 2, Strength: 1 - diff/tolerance}, true
+	}
+	if diff := math.Abs(prev.High - curr.High); trend == 1 && isBullish(prev) && isBearish(curr) && diff <= tolerance {
+		return Pattern{Name: "tweezer_top", Bias: Bearish, Candles: 2, Strength: 1 - diff/tolerance}, true
+	}
+	return Pattern{}, false
+}
@@ -0,0 +1,110 @@
+// Package patterns recognizes classic candlestick patterns in kline data.
+package patterns
+
+import (
+	"math"
+	"tv-bot-go/pkg/kline"
+)
+
+// Bias describes the directional implication of a pattern.
+type Bias string
+
+const (
+	Bullish Bias = "bullish"
+	Bearish Bias = "bearish"
+	Neutral Bias = "neutral"
+)
+
+// Pattern is a candlestick pattern found in a kline series.
+type Pattern struct {
+	Name string `json:"name"`
+	Bias Bias   `json:"bias"`
+	// Index is the position of the last kline that completes the pattern.
+	Index int `json:"index"`
+	// BarsAgo is the number of klines between the pattern and the end of the scanned series.
+	BarsAgo int `json:"bars_ago"`
+	// Candles is the number of klines that make up the pattern.
+	Candles int `json:"candles"`
+	// Strength is a score between 0 and 1 describing how clean the pattern is.
+	Strength float64 `json:"strength"`
+}
+
+// detector checks whether a pattern completes at index i.
+type detector func(klines []kline.Kline, i int, ctx *context) (Pattern, bool)
+
+// detectors is the ordered list of patterns recognized by Scan.
+var detectors = []detector{
+	doji,
+	hammer,
+	invertedHammer,
+	hangingMan,
+	shootingStar,
+	marubozu,
+	engulfing,
+	harami,
+	piercingLine,
+	darkCloudCover,
+	tweezers,
+	morningStar,
+	eveningStar,
+	threeWhiteSoldiers,
+	threeBlackCrows,
+}
+
+// Scan returns every pattern that completes on one of the last lookback klines.
+// Patterns are ordered by kline index, and by detector order within an index.
+func Scan(klines []kline.Kline, lookback int) []Pattern {
+	if len(klines) == 0 {
+		return nil
+	}
+
+	ctx := newContext(klines)
+	var found []Pattern
+	for i := max(len(klines)-lookback, 0); i < len(klines); i++ {
+		for _, detect := range detectors {
+			if p, ok := detect(klines, i, ctx); ok {
+				p.Index = i
+				p.BarsAgo = len(klines) - 1 - i
+				p.Strength = math.Round(math.Min(math.Max(p.Strength, 0), 1)*100) / 100
+				found = append(found, p)
+			}
+		}
+	}
+
+	return found
+}
+
+// context holds series-wide statistics used to judge candle size and trend.
+type context struct {
+	// averageRange is the mean high-low range of the series.
+	averageRange float64
+	closes       []float64
+}
+
+func newContext(klines []kline.Kline) *context {
+	ctx := &context{closes: make([]float64, len(klines))}
+	for i, k := range klines {
+		ctx.averageRange += k.High - k.Low
+		ctx.closes[i] = k.Close
+	}
+	ctx.averageRange /= float64(len(klines))
+	return ctx
+}
+
+// trendBefore returns the direction of the short-term trend leading into index i:
+// 1 for up, -1 for down and 0 when there is not enough data or no clear move.
+func (c *context) trendBefore(i int) int {
+	const window = 5
+	if i < window {
+		return 0
+	}
+	change := c.closes[i-1] - c.closes[i-window]
+	switch {
+	case change > 0.5*c.averageRange:
+		return 1
+	case change < -0.5*c.averageRange:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,85 @@
+package patterns
+
+import (
+	"math"
+	"tv-bot-go/pkg/kline"
+)
+
+// doji is a candle whose open and close are nearly equal.
+func doji(klines []kline.Kline, i int, ctx *context) (Pattern, bool) {
+	k := klines[i]
+	if candleRange(k) == 0 || bodyRatio(k) > 0.1 {
+		return Pattern{}, false
+	}
+	return Pattern{Name: "doji", Bias: Neutral, Candles: 1, Strength: 1 - bodyRatio(k)/0.1}, true
+}
+
+// hammer is a small body with a long lower shadow after a decline.
+func hammer(klines []kline.Kline, i int, ctx *context) (Pattern, bool) {
+	k := klines[i]
+	if ctx.trendBefore(i) != -1 || !hasLongLowerShadow(k) {
+		return Pattern{}, false
+	}
+	return Pattern{Name: "hammer", Bias: Bullish, Candles: 1, Strength: lowerShadow(k) / candleRange(k)}, true
+}
+
+// hangingMan has the shape of a hammer but appears after an advance.
+func hangingMan(klines []kline.Kline, i int, ctx *context) (Pattern, bool) {
+	k := klines[i]
+	if ctx.trendBefore(i) != 1 || !hasLongLowerShadow(k) {
+		return Pattern{}, false
+	}
+	return Pattern{Name: "hanging_man", Bias: Bearish, Candles: 1, Strength: lowerShadow(k) / candleRange(k)}, true
+}
+
+// invertedHammer is a small body with a long upper shadow after a decline.
+func invertedHammer(klines []kline.Kline, i int, ctx *context) (Pattern, bool) {
+	k := klines[i]
+	if ctx.trendBefore(i) != -1 || !hasLongUpperShadow(k) {
+		return Pattern{}, false
+	}
+	return Pattern{Name: "inverted_hammer", Bias: Bullish, Candles: 1, Strength: upperShadow(k) / candleRange(k)}, true
+}
+
+// shootingStar has the shape of an inverted hammer but appears after an advance.
+func shootingStar(klines []kline.Kline, i int, ctx *context) (Pattern, bool) {
+	k := klines[i]
+	if ctx.trendBefore(i) != 1 || !hasLongUpperShadow(k) {
+		return Pattern{}, false
+	}
+	return Pattern{Name: "shooting_star", Bias: Bearish, Candles: 1, Strength: upperShadow(k) / candleRange(k)}, true
+}
+
+// marubozu is a long candle with little or no shadow.
+func marubozu(klines []kline.Kline, i int, ctx *context) (Pattern, bool) {
+	k := klines[i]
+	if !ctx.isLong(k) || bodyRatio(k) < 0.95 {
+		return Pattern{}, false
+	}
+	bias := Bearish
+	if isBullish(k) {
+		bias = Bullish
+	}
+	return Pattern{Name: "marubozu", Bias: bias, Candles: 1, Strength: bodyRatio(k)}, true
+}
+
+// hasLongLowerShadow reports whether the lower shadow is at least twice the body
+// and the upper shadow is negligible.
+func hasLongLowerShadow(k kline.Kline) bool {
+	return body(k) > 0 && lowerShadow(k) >= 2*body(k) && upperShadow(k) <= 0.1*candleRange(k)
+}
+
+// hasLongUpperShadow reports whether the upper shadow is at least twice the body
+// and the lower shadow is negligible.
+func hasLongUpperShadow(k kline.Kline) bool {
+	return body(k) > 0 && upperShadow(k) >= 2*body(k) && lowerShadow(k) <= 0.1*candleRange(k)
+}
+
+// trendScore blends a shape score with a bonus for appearing after the expected trend.
+func trendScore(shape float64, trend, expected int) float64 {
+	score := 0.8 * math.Min(math.Max(shape, 0), 1)
+	if trend == expected {
+		score += 0.2
+	}
+	return score
+}
@@ -0,0 +1,81 @@
+package patterns
+
+import "tv-bot-go/pkg/kline"
+
+// morningStar is a long bearish candle, a small-bodied candle below its close,
+// and a bullish candle closing above the first candle's midpoint.
+func morningStar(klines []kline.Kline, i int, ctx *context) (Pattern, bool) {
+	if i < 2 {
+		return Pattern{}, false
+	}
+	first, star, last := klines[i-2], klines[i-1], klines[i]
+	if !isBearish(first) || !ctx.isLong(first) || !ctx.isSmall(star) ||
+		bodyBottom(star) > first.Close || !isBullish(last) || last.Close <= midpoint(first) {
+		return Pattern{}, false
+	}
+
+	shape := (last.Close - midpoint(first)) / (first.Open - midpoint(first))
+	return Pattern{Name: "morning_star", Bias: Bullish, Candles: 3, Strength: trendScore(shape, ctx.trendBefore(i-2), -1)}, true
+}
+
+// eveningStar is a long bullish candle, a small-bodied candle above its close,
+// and a bearish candle closing below the first candle's midpoint.
+func eveningStar(klines []kline.Kline, i int, ctx *context) (Pattern, bool) {
+	if i < 2 {
+		return Pattern{}, false
+	}
+	first, star, last := klines[i-2], klines[i-1], klines[i]
+	if !isBullish(first) || !ctx.isLong(first) || !ctx.isSmall(star) ||
+		bodyTop(star) < first.Close || !isBearish(last) || last.Close >= midpoint(first) {
+		return Pattern{}, false
+	}
+
+	shape := (midpoint(first) - last.Close) / (midpoint(first) - first.Open)
+	return Pattern{Name: "evening_star", Bias: Bearish, Candles: 3, Strength: trendScore(shape, ctx.trendBefore(i-2), 1)}, true
+}
+
+// threeWhiteSoldiers is three long bullish candles, each opening within the
+// previous body and closing at a new high with a small upper shadow.
+func threeWhiteSoldiers(klines []kline.Kline, i int, ctx *context) (Pattern, bool) {
+	if i < 2 {
+		return Pattern{}, false
+	}
+	strength := 0.0
+	for j := i - 2; j <= i; j++ {
+		k := klines[j]
+		if !isBullish(k) || body(k) < 0.5*ctx.averageRange || upperShadow(k) > 0.3*body(k) {
+			return Pattern{}, false
+		}
+		if j > i-2 {
+			prev := klines[j-1]
+			if k.Close <= prev.Close || k.Open < prev.Open || k.Open > prev.Close {
+				return Pattern{}, false
+			}
+		}
+		strength += bodyRatio(k) / 3
+	}
+	return Pattern{Name: "three_white_soldiers", Bias: Bullish, Candles: 3, Strength: strength}, true
+}
+
+// threeBlackCrows is three long bearish candles, each opening within the
+// previous body and closing at a new low with a small lower shadow.
+func threeBlackCrows(klines []kline.Kline, i int, ctx *context) (Pattern, bool) {
+	if i < 2 {
+		return Pattern{}, false
+	}
+	strength := 0.0
+	for j := i - 2; j <= i; j++ {
+		k := klines[j]
+		if !isBearish(k) || body(k) < 0.5*ctx.averageRange || lowerShadow(k) > 0.3*body(k) {
+			return Pattern{}, false
+		}
+		if j > i-2 {
+			prev := klines[j-1]
+			if k.Close >= prev.Close || k.Open > prev.Open || k.Open < prev.Close {
+				return Pattern{}, false
+			}
+		}
+		strength += bodyRatio(k) / 3
+	}
+	return Pattern{Name: "three_black_crows", Bias: Bearish, Candles: 3, Strength: strength}, true
+}
@@ -0,0 +1,83 @@
+package stats
+
+import "math"
+
+// minHurstChunk is the smallest chunk size used in the rescaled range analysis.
+const minHurstChunk = 8
+
+// Hurst estimates the Hurst exponent of a return series with rescaled range
+// (R/S) analysis. Values above 0.5 suggest a trending (persistent) series,
+// values below 0.5 a mean-reverting one, and 0.5 a random walk. It returns 0
+// if the series is shorter than four of the smallest chunks.
+//
+// Raw R/S overstates the exponent on short series, so the observed R/S is
+// compared with the value expected for independent returns (Anis-Lloyd-Peters)
+// and the exponent is 0.5 plus the slope of the difference.
+func Hurst(returns []float64) float64 {
+	if len(returns) < 4*minHurstChunk {
+		return 0
+	}
+
+	var logSizes, logRS []float64
+	for size := minHurstChunk; size <= len(returns)/2; size *= 2 {
+		total, chunks := 0.0, 0
+		for start := 0; start+size <= len(returns); start += size {
+			if rs := rescaledRange(returns[start : start+size]); rs > 0 {
+				total += rs
+				chunks++
+			}
+		}
+		if chunks == 0 {
+			continue
+		}
+		logSizes = append(logSizes, math.Log(float64(size)))
+		logRS = append(logRS, math.Log(total/float64(chunks))-math.Log(expectedRescaledRange(size)))
+	}
+	if len(logSizes) < 2 {
+		return 0
+	}
+
+	// The deviation from 0.5 is the slope of the excess log(R/S) against log(size).
+	meanX, meanY := Mean(logSizes), Mean(logRS)
+	var cov, varX float64
+	for i := range logSizes {
+		dx := logSizes[i] - meanX
+		cov += dx * (logRS[i] - meanY)
+		varX += dx * dx
+	}
+	return 0.5 + cov/varX
+}
+
+// expectedRescaledRange returns the Anis-Lloyd-Peters expected R/S of n
+// independent, identically distributed values.
+func expectedRescaledRange(n int) float64 {
+	size := float64(n)
+	// The gamma ratio is taken in log space so that it does not overflow.
+	a, _ := math.Lgamma((size - 1) / 2)
+	b, _ := math.Lgamma(size / 2)
+	factor := math.Exp(a-b) / math.Sqrt(math.Pi)
+	sum := 0.0
+	for i := 1; i < n; i++ {
+		sum += math.Sqrt((size - float64(i)) / float64(i))
+	}
+	return (size - 0.5) / size * factor * sum
+}
+
+// rescaledRange returns the range of the cumulative deviations from the mean
+// divided by the standard deviation of the chunk.
+func rescaledRange(chunk []float64) float64 {
+	mean := Mean(chunk)
+	var cumulative, high, low, variance float64
+	for _, v := range chunk {
+		d := v - mean
+		cumulative += d
+		high = math.Max(high, cumulative)
+		low = math.Min(low, cumulative)
+		variance += d * d
+	}
+	std := math.Sqrt(variance / float64(len(chunk)))
+	if std == 0 {
+		return 0
+	}
+	return (high - low) / std
+}
@@ -0,0 +1,133 @@
+// Package stats computes statistical features of price and return series.
+package stats
+
+import "math"
+
+// LogReturns returns the log return between consecutive prices.
+// The result has one value fewer than the input.
+func LogReturns(prices []float64) []float64 {
+	if len(prices) < 2 {
+		return nil
+	}
+	returns := make([]float64, len(prices)-1)
+	for i := 1; i < len(prices); i++ {
+		if prices[i-1] > 0 && prices[i] > 0 {
+			returns[i-1] = math.Log(prices[i] / prices[i-1])
+		}
+	}
+	return returns
+}
+
+// Mean returns the arithmetic mean of values, or 0 if values is empty.
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// StdDev returns the sample standard deviation of values.
+func StdDev(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	mean := Mean(values)
+	sum := 0.0
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(sum / float64(len(values)-1))
+}
+
+// ZScore returns how many standard deviations the last value is from the
+// mean of the last window values, including itself.
+func ZScore(values []float64, window int) float64 {
+	if window < 2 || len(values) < window {
+		return 0
+	}
+	recent := values[len(values)-window:]
+	std := StdDev(recent)
+	if std == 0 {
+		return 0
+	}
+	return (recent[len(recent)-1] - Mean(recent)) / std
+}
+
+// ZScores returns the rolling z-score of every value with a full window.
+// The result is aligned to the end of the input.
+func ZScores(values []float64, window int) []float64 {
+	if window < 2 || len(values) < window {
+		return nil
+	}
+	result := make([]float64, len(values)-window+1)
+	for i := range result {
+		result[i] = ZScore(values[:i+window], window)
+	}
+	return result
+}
+
+// Skewness returns the sample skewness of values. Positive skew means the
+// right tail is longer.
+func Skewness(values []float64) float64 {
+	n := float64(len(values))
+	if n < 3 {
+		return 0
+	}
+	mean := Mean(values)
+	var m2, m3 float64
+	for _, v := range values {
+		d := v - mean
+		m2 += d * d
+		m3 += d * d * d
+	}
+	m2, m3 = m2/n, m3/n
+	if m2 == 0 {
+		return 0
+	}
+	return m3 / math.Pow(m2, 1.5)
+}
+
+// Kurtosis returns the excess kurtosis of values: 0 for a normal
+// distribution and positive for fat tails.
+func Kurtosis(values []float64) float64 {
+	n := float64(len(values))
+	if n < 4 {
+		return 0
+	}
+	mean := Mean(values)
+	var m2, m4 float64
+	for _, v := range values {
+		d := v - mean
+		m2 += d * d
+		m4 += d * d * d * d
+	}
+	m2, m4 = m2/n, m4/n
+	if m2 == 0 {
+		return 0
+	}
+	return m4/(m2*m2) - 3
+}
+
+// Autocorrelation returns the correlation of values with themselves shifted by lag.
+func Autocorrelation(values []float64, lag int) float64 {
+	if lag < 1 || len(values) <= lag+1 {
+		return 0
+	}
+	mean := Mean(values)
+	var num, den float64
+	for i, v := range values {
+		d := v - mean
+		den += d * d
+		if i >= lag {
+			num += d * (values[i-lag] - mean)
+		}
+	}
+	if den == 0 {
+		return 0
+	}
+	return num / den
+}
@@ -0,0 +1,75 @@
+package stats
+
+import (
+	"math"
+	"time"
+	"tv-bot-go/pkg/kline"
+)
+
+// RealizedVolatility returns the per-bar volatility of close-to-close log
+// returns, as the standard deviation of the returns.
+func RealizedVolatility(klines []kline.Kline) float64 {
+	closes := make([]float64, len(klines))
+	for i, k := range klines {
+		closes[i] = k.Close
+	}
+	return StdDev(LogReturns(closes))
+}
+
+// ParkinsonVolatility returns the per-bar volatility estimated from the
+// high-low range of each kline. It is more efficient than close-to-close
+// volatility but ignores overnight gaps and drift.
+func ParkinsonVolatility(klines []kline.Kline) float64 {
+	n := 0
+	sum := 0.0
+	for _, k := range klines {
+		if k.High <= 0 || k.Low <= 0 {
+			continue
+		}
+		hl := math.Log(k.High / k.Low)
+		sum += hl * hl
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+	return math.Sqrt(sum / (4 * float64(n) * math.Ln2))
+}
+
+// GarmanKlassVolatility returns the per-bar volatility estimated from the
+// open, high, low and close of each kline.
+func GarmanKlassVolatility(klines []kline.Kline) float64 {
+	n := 0
+	sum := 0.0
+	for _, k := range klines {
+		if k.High <= 0 || k.Low <= 0 || k.Open <= 0 || k.Close <= 0 {
+			continue
+		}
+		hl := math.Log(k.High / k.Low)
+		co := math.Log(k.Close / k.Open)
+		sum += 0.5*hl*hl - (2*math.Ln2-1)*co*co
+		n++
+	}
+	if n == 0 || sum <= 0 {
+		return 0
+	}
+	return math.Sqrt(sum / float64(n))
+}
+
+// Annualize scales a per-bar volatility to one year of bars of the given duration.
+func Annualize(volatility float64, bar time.Duration) float64 {
+	if bar <= 0 {
+		return 0
+	}
+	barsPerYear := float64(365*24*time.Hour) / float64(bar)
+	return volatility * math.Sqrt(barsPerYear)
+}
+
+// BarDuration returns the average time covered by a kline in the series.
+func BarDuration(klines []kline.Kline) time.Duration {
+	if len(klines) == 0 {
+		return 0
+	}
+	span := klines[len(klines)-1].CloseTime + 1 - klines[0].OpenTime
+	return time.Duration(span/int64(len(klines))) * time.Millisecond
+}